@@ -0,0 +1,163 @@
+// Package builder offers a fluent, immutable SQL builder decoupled from
+// execution, the layer the ngorm package doc has always promised but
+// that previously only existed inline in scope. Every method returns a
+// new *Builder, so a partially built query can be reused as a base for
+// several variations without aliasing.
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gernest/ngorm/dialects"
+)
+
+type kind int
+
+const (
+	kindSelect kind = iota
+	kindInsert
+	kindUpdate
+	kindDelete
+	kindUnion
+)
+
+// Builder accumulates the pieces of a single statement. Build it with
+// Select/Insert/Update/Delete/Union and the chained methods below, then
+// call ToSQL with the dialect that will run it.
+type Builder struct {
+	kind  kind
+	table string
+	cols  []string
+	where Cond
+
+	orderBy []string
+	limit   int
+	offset  int
+
+	sets       map[string]interface{}
+	setOrder   []string
+	insertVals []interface{}
+
+	members  []*Builder
+	unionAll bool
+}
+
+// Select starts a SELECT statement over the given columns.
+func Select(cols ...string) *Builder {
+	return &Builder{kind: kindSelect, cols: cols, limit: -1}
+}
+
+// From names the table the statement reads from or writes to.
+func (b *Builder) From(table string) *Builder {
+	n := b.clone()
+	n.table = table
+	return n
+}
+
+// Where sets (or replaces) the statement's predicate.
+func (b *Builder) Where(c Cond) *Builder {
+	n := b.clone()
+	n.where = c
+	return n
+}
+
+// And combines the existing predicate with c using AND.
+func (b *Builder) And(c Cond) *Builder {
+	n := b.clone()
+	if n.where == nil {
+		n.where = c
+	} else {
+		n.where = andCond{n.where, c}
+	}
+	return n
+}
+
+// Or combines the existing predicate with c using OR.
+func (b *Builder) Or(c Cond) *Builder {
+	n := b.clone()
+	if n.where == nil {
+		n.where = c
+	} else {
+		n.where = orCond{n.where, c}
+	}
+	return n
+}
+
+// OrderBy appends one or more "column [ASC|DESC]" clauses.
+func (b *Builder) OrderBy(cols ...string) *Builder {
+	n := b.clone()
+	n.orderBy = append(append([]string{}, n.orderBy...), cols...)
+	return n
+}
+
+// Limit caps the result set to limit rows starting at offset.
+func (b *Builder) Limit(limit, offset int) *Builder {
+	n := b.clone()
+	n.limit = limit
+	n.offset = offset
+	return n
+}
+
+func (b *Builder) clone() *Builder {
+	n := *b
+	n.cols = append([]string{}, b.cols...)
+	n.orderBy = append([]string{}, b.orderBy...)
+	n.setOrder = append([]string{}, b.setOrder...)
+	if b.sets != nil {
+		n.sets = make(map[string]interface{}, len(b.sets))
+		for k, v := range b.sets {
+			n.sets[k] = v
+		}
+	}
+	n.members = append([]*Builder{}, b.members...)
+	return &n
+}
+
+// ToSQL renders the statement for d, returning the query and its
+// positional arguments in the order the placeholders appear.
+func (b *Builder) ToSQL(d dialects.Dialect) (string, []interface{}, error) {
+	switch b.kind {
+	case kindSelect:
+		return b.selectSQL(d)
+	case kindInsert:
+		return b.insertSQL(d)
+	case kindUpdate:
+		return b.updateSQL(d)
+	case kindDelete:
+		return b.deleteSQL(d)
+	case kindUnion:
+		return b.unionSQL(d)
+	default:
+		return "", nil, fmt.Errorf("builder: unknown statement kind %d", b.kind)
+	}
+}
+
+func (b *Builder) selectSQL(d dialects.Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, fmt.Errorf("builder: Select missing From")
+	}
+	cols := "*"
+	if len(b.cols) > 0 {
+		cols = strings.Join(b.cols, ", ")
+	}
+	var buf strings.Builder
+	var args []interface{}
+	fmt.Fprintf(&buf, "SELECT %s FROM %s", cols, b.table)
+	if b.where != nil {
+		clause, err := b.where.build(d, &args)
+		if err != nil {
+			return "", nil, err
+		}
+		buf.WriteString(" WHERE ")
+		buf.WriteString(clause)
+	}
+	if len(b.orderBy) > 0 {
+		buf.WriteString(" ORDER BY ")
+		buf.WriteString(strings.Join(b.orderBy, ", "))
+	}
+	if b.limit >= 0 {
+		buf.WriteString(d.LimitAndOffsetSQL(b.limit, b.offset))
+	}
+	return buf.String(), args, nil
+}
@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/gernest/ngorm/dialects/mysql"
+	"github.com/gernest/ngorm/dialects/postgres"
+)
+
+func TestSelectSQL(t *testing.T) {
+	b := Select("id", "name").From("users").Where(Eq{"active": true}).OrderBy("id ASC").Limit(10, 5)
+
+	sql, args, err := b.ToSQL(&mysql.Dialect{})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+	wantSQL := "SELECT id, name FROM users WHERE `active` = ? ORDER BY id ASC LIMIT 10 OFFSET 5"
+	if sql != wantSQL {
+		t.Errorf("sql = %q, want %q", sql, wantSQL)
+	}
+	if len(args) != 1 || args[0] != true {
+		t.Errorf("args = %v, want [true]", args)
+	}
+}
+
+func TestUpdateSQLWithRawSet(t *testing.T) {
+	b := Update("accounts").Where(Eq{"id": 1}).Set("version", Raw("version + 1")).Set("balance", 100)
+
+	sql, args, err := b.ToSQL(&postgres.Dialect{})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+	wantSQL := `UPDATE accounts SET "version" = version + 1, "balance" = $1 WHERE "id" = $2`
+	if sql != wantSQL {
+		t.Errorf("sql = %q, want %q", sql, wantSQL)
+	}
+	if len(args) != 2 || args[0] != 100 || args[1] != 1 {
+		t.Errorf("args = %v, want [100 1]", args)
+	}
+}
+
+func TestInPlaceholderPerValue(t *testing.T) {
+	b := Select("id").From("users").Where(In{Col: "id", Values: []interface{}{1, 2, 3}})
+
+	sql, args, err := b.ToSQL(&postgres.Dialect{})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+	wantSQL := `SELECT id FROM users WHERE "id" IN ($1, $2, $3)`
+	if sql != wantSQL {
+		t.Errorf("sql = %q, want %q", sql, wantSQL)
+	}
+	if len(args) != 3 {
+		t.Errorf("args = %v, want 3 values", args)
+	}
+}
+
+func TestDeleteSQL(t *testing.T) {
+	b := Delete("sessions").Where(Lt{"expires_at": 100})
+
+	sql, args, err := b.ToSQL(&mysql.Dialect{})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+	wantSQL := "DELETE FROM sessions WHERE `expires_at` < ?"
+	if sql != wantSQL {
+		t.Errorf("sql = %q, want %q", sql, wantSQL)
+	}
+	if len(args) != 1 || args[0] != 100 {
+		t.Errorf("args = %v, want [100]", args)
+	}
+}
+
+func TestInsertMissingIntoErrors(t *testing.T) {
+	b := Insert("id").Values(1)
+	if _, _, err := b.ToSQL(&mysql.Dialect{}); err == nil {
+		t.Fatal("expected an error for Insert with no Into")
+	}
+}
@@ -0,0 +1,171 @@
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gernest/ngorm/dialects"
+)
+
+// Cond renders one predicate or a combination of predicates into a SQL
+// fragment, appending its arguments to args in the order its
+// placeholders appear so the caller ends up with a single args slice
+// that lines up with the full statement.
+type Cond interface {
+	build(d dialects.Dialect, args *[]interface{}) (string, error)
+}
+
+// Eq builds "col = ?" (or "$N", per dialect) for every key, ANDed
+// together. Keys are sorted so the generated SQL is deterministic.
+type Eq map[string]interface{}
+
+func (e Eq) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return buildComparison(d, args, e, "=")
+}
+
+// Gt builds "col > ?" for every key, ANDed together.
+type Gt map[string]interface{}
+
+func (e Gt) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return buildComparison(d, args, e, ">")
+}
+
+// Gte builds "col >= ?" for every key, ANDed together.
+type Gte map[string]interface{}
+
+func (e Gte) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return buildComparison(d, args, e, ">=")
+}
+
+// Lt builds "col < ?" for every key, ANDed together.
+type Lt map[string]interface{}
+
+func (e Lt) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return buildComparison(d, args, e, "<")
+}
+
+// Lte builds "col <= ?" for every key, ANDed together.
+type Lte map[string]interface{}
+
+func (e Lte) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return buildComparison(d, args, e, "<=")
+}
+
+// Neq builds "col <> ?" for every key, ANDed together.
+type Neq map[string]interface{}
+
+func (e Neq) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return buildComparison(d, args, e, "<>")
+}
+
+// SubQuery wraps b so it can be used as the value side of an Eq/Gt/...
+// condition or an In, rendering as a parenthesized sub-query instead of
+// a bound placeholder: Eq{"dept_id": SubQuery(Select("id").From("depts"))}.
+func SubQuery(b *Builder) interface{} {
+	return subQuery{b}
+}
+
+type subQuery struct {
+	b *Builder
+}
+
+func buildComparison(d dialects.Dialect, args *[]interface{}, m map[string]interface{}, op string) (string, error) {
+	if len(m) == 0 {
+		return "", fmt.Errorf("builder: empty condition")
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		rhs, err := renderValue(d, args, m[k])
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s", d.Quote(k), op, rhs))
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+func renderValue(d dialects.Dialect, args *[]interface{}, v interface{}) (string, error) {
+	if sq, ok := v.(subQuery); ok {
+		sql, subArgs, err := sq.b.ToSQL(offsetDialect{d, len(*args)})
+		if err != nil {
+			return "", err
+		}
+		*args = append(*args, subArgs...)
+		return "(" + sql + ")", nil
+	}
+	placeholder := d.BindVar(len(*args))
+	*args = append(*args, v)
+	return placeholder, nil
+}
+
+// offsetDialect numbers placeholders starting after off arguments, so a
+// nested builder rendered on its own continues the enclosing
+// statement's numbering instead of restarting it.
+type offsetDialect struct {
+	dialects.Dialect
+	off int
+}
+
+func (o offsetDialect) BindVar(i int) string {
+	return o.Dialect.BindVar(o.off + i)
+}
+
+// In builds "col IN (?, ?, ...)", or "col IN (SELECT ...)" when its only
+// value is a SubQuery.
+type In struct {
+	Col    string
+	Values []interface{}
+}
+
+func (i In) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	if len(i.Values) == 0 {
+		return "", fmt.Errorf("builder: In %s has no values", i.Col)
+	}
+	if sq, ok := i.Values[0].(subQuery); ok && len(i.Values) == 1 {
+		rhs, err := renderValue(d, args, sq)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s IN %s", d.Quote(i.Col), rhs), nil
+	}
+	placeholders := make([]string, len(i.Values))
+	for idx, v := range i.Values {
+		placeholders[idx] = d.BindVar(len(*args))
+		*args = append(*args, v)
+	}
+	return fmt.Sprintf("%s IN (%s)", d.Quote(i.Col), strings.Join(placeholders, ", ")), nil
+}
+
+type andCond struct {
+	left, right Cond
+}
+
+func (c andCond) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return combine(d, args, c.left, c.right, "AND")
+}
+
+type orCond struct {
+	left, right Cond
+}
+
+func (c orCond) build(d dialects.Dialect, args *[]interface{}) (string, error) {
+	return combine(d, args, c.left, c.right, "OR")
+}
+
+func combine(d dialects.Dialect, args *[]interface{}, left, right Cond, op string) (string, error) {
+	l, err := left.build(d, args)
+	if err != nil {
+		return "", err
+	}
+	r, err := right.build(d, args)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+}
@@ -0,0 +1,172 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gernest/ngorm/dialects"
+)
+
+// Insert starts an INSERT statement. Call Into to name the table and
+// Values to supply the row.
+func Insert(cols ...string) *Builder {
+	return &Builder{kind: kindInsert, cols: cols, limit: -1}
+}
+
+// Into names the table an Insert writes to.
+func (b *Builder) Into(table string) *Builder {
+	n := b.clone()
+	n.table = table
+	return n
+}
+
+// Values supplies one row of values, positional with the columns passed
+// to Insert.
+func (b *Builder) Values(vals ...interface{}) *Builder {
+	n := b.clone()
+	n.insertVals = append([]interface{}{}, vals...)
+	return n
+}
+
+func (b *Builder) insertSQL(d dialects.Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, fmt.Errorf("builder: Insert missing Into")
+	}
+	if len(b.cols) == 0 {
+		return "", nil, fmt.Errorf("builder: Insert missing columns")
+	}
+	if len(b.insertVals) != len(b.cols) {
+		return "", nil, fmt.Errorf("builder: Insert has %d columns but %d values", len(b.cols), len(b.insertVals))
+	}
+	var args []interface{}
+	placeholders := make([]string, len(b.cols))
+	quoted := make([]string, len(b.cols))
+	for i, c := range b.cols {
+		quoted[i] = d.Quote(c)
+		placeholders[i] = d.BindVar(len(args))
+		args = append(args, b.insertVals[i])
+	}
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		b.table, strings.Join(quoted, ", "), strings.Join(placeholders, ", "))
+	return sql, args, nil
+}
+
+// Update starts an UPDATE statement against table.
+func Update(table string) *Builder {
+	return &Builder{kind: kindUpdate, table: table, limit: -1}
+}
+
+// Set adds a "col = value" assignment. Repeated calls accumulate
+// assignments in call order.
+func (b *Builder) Set(col string, value interface{}) *Builder {
+	n := b.clone()
+	if n.sets == nil {
+		n.sets = map[string]interface{}{}
+	}
+	if _, exists := n.sets[col]; !exists {
+		n.setOrder = append(n.setOrder, col)
+	}
+	n.sets[col] = value
+	return n
+}
+
+// RawExpr is a literal SQL fragment with its own placeholder args, for
+// use as a Set value when the assignment isn't a plain bound value, e.g.
+// Set("version", Raw("version + 1")).
+type RawExpr struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Raw wraps sql as a RawExpr, to be used as a Set value.
+func Raw(sql string, args ...interface{}) RawExpr {
+	return RawExpr{SQL: sql, Args: args}
+}
+
+func (b *Builder) updateSQL(d dialects.Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, fmt.Errorf("builder: Update missing table")
+	}
+	if len(b.setOrder) == 0 {
+		return "", nil, fmt.Errorf("builder: Update has no Set assignments")
+	}
+	var args []interface{}
+	assignments := make([]string, len(b.setOrder))
+	for i, col := range b.setOrder {
+		if raw, ok := b.sets[col].(RawExpr); ok {
+			assignments[i] = fmt.Sprintf("%s = %s", d.Quote(col), raw.SQL)
+			args = append(args, raw.Args...)
+			continue
+		}
+		assignments[i] = fmt.Sprintf("%s = %s", d.Quote(col), d.BindVar(len(args)))
+		args = append(args, b.sets[col])
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "UPDATE %s SET %s", b.table, strings.Join(assignments, ", "))
+	if b.where != nil {
+		clause, err := b.where.build(d, &args)
+		if err != nil {
+			return "", nil, err
+		}
+		buf.WriteString(" WHERE ")
+		buf.WriteString(clause)
+	}
+	return buf.String(), args, nil
+}
+
+// Delete starts a DELETE statement against table.
+func Delete(table string) *Builder {
+	return &Builder{kind: kindDelete, table: table, limit: -1}
+}
+
+func (b *Builder) deleteSQL(d dialects.Dialect) (string, []interface{}, error) {
+	if b.table == "" {
+		return "", nil, fmt.Errorf("builder: Delete missing table")
+	}
+	var args []interface{}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "DELETE FROM %s", b.table)
+	if b.where != nil {
+		clause, err := b.where.build(d, &args)
+		if err != nil {
+			return "", nil, err
+		}
+		buf.WriteString(" WHERE ")
+		buf.WriteString(clause)
+	}
+	return buf.String(), args, nil
+}
+
+// Union combines two or more SELECT builders with UNION. Use UnionAll to
+// switch to UNION ALL.
+func Union(members ...*Builder) *Builder {
+	return &Builder{kind: kindUnion, members: members, limit: -1}
+}
+
+// UnionAll marks the union as UNION ALL instead of UNION.
+func (b *Builder) UnionAll() *Builder {
+	n := b.clone()
+	n.unionAll = true
+	return n
+}
+
+func (b *Builder) unionSQL(d dialects.Dialect) (string, []interface{}, error) {
+	if len(b.members) == 0 {
+		return "", nil, fmt.Errorf("builder: Union has no members")
+	}
+	op := " UNION "
+	if b.unionAll {
+		op = " UNION ALL "
+	}
+	var args []interface{}
+	parts := make([]string, len(b.members))
+	for i, m := range b.members {
+		sql, memberArgs, err := m.ToSQL(offsetDialect{d, len(args)})
+		if err != nil {
+			return "", nil, err
+		}
+		parts[i] = sql
+		args = append(args, memberArgs...)
+	}
+	return strings.Join(parts, op), args, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateInMethodMatchesPlaceholdersToValues(t *testing.T) {
+	models := []qsModel{{
+		Name: "User",
+		Fields: []qsField{
+			{Name: "ID", GoType: "int64", Column: "id"},
+		},
+	}}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "users_qs.go")
+	if err := generate(out, "models", models); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	src, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+
+	want := `qs.where("id IN ("+strings.Join(placeholders, ", ")+")", args...)`
+	wantFormatted := `qs.where("id IN (" + strings.Join(placeholders, ", ") + ")", args...)`
+	if !strings.Contains(string(src), want) && !strings.Contains(string(src), wantFormatted) {
+		t.Fatalf("generated IDIn method does not build one placeholder per value:\n%s", src)
+	}
+}
@@ -0,0 +1,60 @@
+// Command ngormgen generates type-safe QuerySet methods for every struct
+// marked with a "// ngorm:qs" doc comment, turning field-by-field
+// filtering and ordering into compile-time-checked method calls instead
+// of hand-written query strings. Typical use is via go:generate next to
+// the model:
+//
+//	//go:generate ngormgen -file $GOFILE
+//	// ngorm:qs
+//	type User struct {
+//		ID     int64
+//		Rating int `ngorm:"column:rating"`
+//	}
+//
+// running `go generate` produces a sibling user_ngorm.go defining
+// UserQuerySet with RatingEq, RatingGt, RatingIn, OrderAscByRating, and
+// the terminal All/One/Count/Update/Delete methods.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	file := flag.String("file", os.Getenv("GOFILE"), "Go source file to scan for ngorm:qs models")
+	out := flag.String("out", "", "output file (default: <file> with .go replaced by _ngorm.go)")
+	flag.Parse()
+
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "ngormgen: -file is required (or set GOFILE, as go:generate does)")
+		os.Exit(1)
+	}
+
+	pkg, models, err := parseModels(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ngormgen:", err)
+		os.Exit(1)
+	}
+	if len(models) == 0 {
+		return
+	}
+
+	outPath := *out
+	if outPath == "" {
+		outPath = outputPath(*file)
+	}
+	if err := generate(outPath, pkg, models); err != nil {
+		fmt.Fprintln(os.Stderr, "ngormgen:", err)
+		os.Exit(1)
+	}
+}
+
+func outputPath(file string) string {
+	if strings.HasSuffix(file, ".go") {
+		return strings.TrimSuffix(file, ".go") + "_ngorm.go"
+	}
+	return file + "_ngorm.go"
+}
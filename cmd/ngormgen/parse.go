@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// qsField describes one eligible struct field: its Go name, its Go type
+// as written in source, and the column name ngorm will bind it to.
+type qsField struct {
+	Name   string
+	GoType string
+	Column string
+}
+
+// qsModel is one "// ngorm:qs" struct found in the scanned file.
+type qsModel struct {
+	Name   string
+	Fields []qsField
+}
+
+func parseModels(file string) (pkg string, models []qsModel, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		gd, ok := n.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE || !hasQSMarker(gd.Doc) {
+			return true
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			models = append(models, qsModel{
+				Name:   ts.Name.Name,
+				Fields: structFields(st),
+			})
+		}
+		return true
+	})
+	return f.Name.Name, models, nil
+}
+
+func hasQSMarker(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.Contains(c.Text, "ngorm:qs") {
+			return true
+		}
+	}
+	return false
+}
+
+func structFields(st *ast.StructType) []qsField {
+	var out []qsField
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			continue // embedded field, nothing to generate a method for
+		}
+		typ := exprString(f.Type)
+		tag := tagValue(f.Tag)
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+			out = append(out, qsField{
+				Name:   name.Name,
+				GoType: typ,
+				Column: columnName(name.Name, tag),
+			})
+		}
+	}
+	return out
+}
+
+func tagValue(lit *ast.BasicLit) string {
+	if lit == nil {
+		return ""
+	}
+	raw, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(raw).Get("ngorm")
+}
+
+func columnName(fieldName, tag string) string {
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "column:") {
+			return strings.TrimPrefix(part, "column:")
+		}
+	}
+	return toSnake(fieldName)
+}
+
+func toSnake(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+func exprString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	default:
+		return fmt.Sprintf("%T", e)
+	}
+}
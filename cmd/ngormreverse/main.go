@@ -0,0 +1,93 @@
+// Command ngormreverse connects to an existing database through any
+// registered ngorm dialect and emits Go struct definitions for its
+// tables, tagged so ngorm can use them as-is. This lets users adopt
+// ngorm on a legacy schema without hand-writing models.
+//
+//	ngormreverse -dialect postgres -dsn "$DSN" -package models -out models/models_gen.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gernest/ngorm"
+	"github.com/gernest/ngorm/reverse"
+)
+
+func main() {
+	dialect := flag.String("dialect", "", "registered dialect name (mysql, postgres, sqlite3, ql, ...)")
+	dsn := flag.String("dsn", "", "data source name / connection string")
+	pkg := flag.String("package", "models", "package name for the generated file")
+	out := flag.String("out", "", "output file (default: stdout)")
+	templateFile := flag.String("template", "", "optional text/template file overriding the default struct layout")
+	include := flag.String("include", "", "comma-separated glob(s); only matching tables are emitted")
+	exclude := flag.String("exclude", "", "comma-separated glob(s); matching tables are skipped")
+	flag.Parse()
+
+	if err := run(*dialect, *dsn, *pkg, *out, *templateFile, *include, *exclude); err != nil {
+		fmt.Fprintln(os.Stderr, "ngormreverse:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dialect, dsn, pkg, out, templateFile, include, exclude string) error {
+	if dialect == "" || dsn == "" {
+		return fmt.Errorf("-dialect and -dsn are required")
+	}
+
+	db, err := ngorm.Open(dialect, dsn)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", dialect, err)
+	}
+
+	introspector, ok := db.Dialect().(reverse.Introspector)
+	if !ok {
+		return fmt.Errorf("dialect %s does not support introspection", dialect)
+	}
+
+	tables, err := introspector.Introspect(context.Background())
+	if err != nil {
+		return fmt.Errorf("introspect: %w", err)
+	}
+
+	tables, err = reverse.Filter(tables, splitGlobs(include), splitGlobs(exclude))
+	if err != nil {
+		return fmt.Errorf("filter tables: %w", err)
+	}
+
+	tmplText := ""
+	if templateFile != "" {
+		b, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("read template: %w", err)
+		}
+		tmplText = string(b)
+	}
+
+	src, err := reverse.Generate(pkg, tables, tmplText)
+	if err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+func splitGlobs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package ngorm
+
+import (
+	"database/sql"
+
+	"github.com/gernest/ngorm/builder"
+)
+
+// Select starts a SELECT chain off of db, so callers don't need a
+// separate import just to build one. Its result is only useful once
+// handed to db.Query.
+func (db *DB) Select(cols ...string) *builder.Builder {
+	return builder.Select(cols...)
+}
+
+// Insert starts an INSERT chain off of db. Its result is only useful
+// once handed to db.ExecBuilder.
+func (db *DB) Insert(cols ...string) *builder.Builder {
+	return builder.Insert(cols...)
+}
+
+// Update starts an UPDATE chain off of db. Its result is only useful
+// once handed to db.ExecBuilder.
+func (db *DB) Update(table string) *builder.Builder {
+	return builder.Update(table)
+}
+
+// Delete starts a DELETE chain off of db. Its result is only useful
+// once handed to db.ExecBuilder.
+func (db *DB) Delete(table string) *builder.Builder {
+	return builder.Delete(table)
+}
+
+// Query renders b for this DB's dialect and runs it, returning the rows
+// so the generated SQL can still be inspected (via b.ToSQL) for tuning
+// before or after execution.
+func (db *DB) Query(b *builder.Builder) (*sql.Rows, error) {
+	q, args, err := b.ToSQL(db.dialect)
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryTx(q, args...)
+}
+
+// ExecBuilder renders b for this DB's dialect and executes it through
+// ExecTx, for Insert/Update/Delete builders.
+func (db *DB) ExecBuilder(b *builder.Builder) (sql.Result, error) {
+	q, args, err := b.ToSQL(db.dialect)
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecTx(q, args...)
+}
@@ -0,0 +1,49 @@
+package ngorm
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gernest/ngorm/engine"
+)
+
+// BeginTx starts a transaction on db's connection and returns both the
+// *sql.Tx, for callers that need to run their own bookkeeping
+// statements alongside it, and an *engine.Engine that executes against
+// that same transaction. A caller such as the migrations package uses
+// this to make a step and its bookkeeping write atomic.
+func (db *DB) BeginTx() (*sql.Tx, *engine.Engine, error) {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, db.engineWithConn(&txConn{tx: tx}), nil
+}
+
+// txConn adapts a *sql.Tx to model.SQLCommon so engineWithConn can hand
+// out an engine that runs against a transaction already in progress.
+// Begin errors instead of opening a nested transaction, since *sql.Tx
+// doesn't support that.
+type txConn struct {
+	tx *sql.Tx
+}
+
+func (c *txConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.tx.Exec(query, args...)
+}
+
+func (c *txConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.tx.Query(query, args...)
+}
+
+func (c *txConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.tx.QueryRow(query, args...)
+}
+
+func (c *txConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.tx.Prepare(query)
+}
+
+func (c *txConn) Begin() (*sql.Tx, error) {
+	return nil, fmt.Errorf("ngorm: %T is already inside a transaction", c)
+}
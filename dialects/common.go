@@ -0,0 +1,55 @@
+package dialects
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// QuestionMarkBindVar is the BindVar implementation for dialects whose
+// driver uses unnumbered "?" placeholders (MySQL, SQLite).
+func QuestionMarkBindVar(i int) string {
+	return "?"
+}
+
+// DoubleQuote is the Quote implementation for dialects that quote
+// identifiers with ANSI double quotes (Postgres, SQLite).
+func DoubleQuote(key string) string {
+	return fmt.Sprintf("%q", key)
+}
+
+// StandardLimitAndOffsetSQL renders the "LIMIT n OFFSET m" clause
+// shared by MySQL, Postgres and SQLite.
+func StandardLimitAndOffsetSQL(limit, offset int) string {
+	if limit < 0 {
+		return ""
+	}
+	sql := fmt.Sprintf(" LIMIT %d", limit)
+	if offset > 0 {
+		sql += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return sql
+}
+
+// CommonDataTypeOf maps t to the SQL type every supported dialect
+// agrees on, for the Go kinds where they don't differ enough to need
+// dialect-specific handling. It returns "" for kinds a dialect needs to
+// resolve itself, e.g. integers (AUTO_INCREMENT vs SERIAL) and strings
+// (sizing).
+func CommonDataTypeOf(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return "TIMESTAMP"
+	case t.Kind() == reflect.Bool:
+		return "BOOLEAN"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "FLOAT"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return "BLOB"
+	default:
+		return ""
+	}
+}
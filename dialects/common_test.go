@@ -0,0 +1,43 @@
+package dialects
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStandardLimitAndOffsetSQL(t *testing.T) {
+	cases := []struct {
+		limit, offset int
+		want          string
+	}{
+		{-1, 0, ""},
+		{10, 0, " LIMIT 10"},
+		{10, 5, " LIMIT 10 OFFSET 5"},
+	}
+	for _, c := range cases {
+		if got := StandardLimitAndOffsetSQL(c.limit, c.offset); got != c.want {
+			t.Errorf("StandardLimitAndOffsetSQL(%d, %d) = %q, want %q", c.limit, c.offset, got, c.want)
+		}
+	}
+}
+
+func TestCommonDataTypeOf(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{true, "BOOLEAN"},
+		{float64(0), "FLOAT"},
+		{time.Time{}, "TIMESTAMP"},
+		{[]byte(nil), "BLOB"},
+		{0, ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		got := CommonDataTypeOf(reflect.TypeOf(c.value))
+		if got != c.want {
+			t.Errorf("CommonDataTypeOf(%T) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
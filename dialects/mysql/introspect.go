@@ -0,0 +1,151 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gernest/ngorm/reverse"
+)
+
+// Introspect reads INFORMATION_SCHEMA for the connection's current
+// database and returns one reverse.TableInfo per base table.
+func (d *Dialect) Introspect(ctx context.Context) ([]reverse.TableInfo, error) {
+	rows, err := d.db.Query(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'`)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tables := make([]reverse.TableInfo, 0, len(names))
+	for _, name := range names {
+		cols, err := d.columns(name)
+		if err != nil {
+			return nil, err
+		}
+		indexes, err := d.indexes(name)
+		if err != nil {
+			return nil, err
+		}
+		fks, err := d.foreignKeys(name)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, reverse.TableInfo{
+			Name:        name,
+			Columns:     cols,
+			Indexes:     indexes,
+			ForeignKeys: fks,
+		})
+	}
+	return tables, nil
+}
+
+func (d *Dialect) columns(table string) ([]reverse.Column, error) {
+	rows, err := d.db.Query(`
+		SELECT column_name, data_type, is_nullable, column_key, character_maximum_length
+		FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = ?
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: columns for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var cols []reverse.Column
+	for rows.Next() {
+		var name, dataType, nullable, key string
+		var size *int
+		if err := rows.Scan(&name, &dataType, &nullable, &key, &size); err != nil {
+			return nil, err
+		}
+		c := reverse.Column{
+			Name:       name,
+			Type:       dataType,
+			Nullable:   nullable == "YES",
+			PrimaryKey: key == "PRI",
+		}
+		if size != nil {
+			c.Size = *size
+		}
+		cols = append(cols, c)
+	}
+	return cols, rows.Err()
+}
+
+// indexes reads table's non-primary indexes from information_schema,
+// preserving column order within each index via seq_in_index.
+func (d *Dialect) indexes(table string) ([]reverse.Index, error) {
+	rows, err := d.db.Query(`
+		SELECT index_name, non_unique, column_name
+		FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ? AND index_name != 'PRIMARY'
+		ORDER BY index_name, seq_in_index`, table)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: indexes for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var order []string
+	byName := make(map[string]*reverse.Index)
+	for rows.Next() {
+		var name, column string
+		var nonUnique int
+		if err := rows.Scan(&name, &nonUnique, &column); err != nil {
+			return nil, err
+		}
+		idx, ok := byName[name]
+		if !ok {
+			idx = &reverse.Index{Name: name, Unique: nonUnique == 0}
+			byName[name] = idx
+			order = append(order, name)
+		}
+		idx.Columns = append(idx.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]reverse.Index, 0, len(order))
+	for _, name := range order {
+		indexes = append(indexes, *byName[name])
+	}
+	return indexes, nil
+}
+
+// foreignKeys reads table's foreign key constraints from
+// information_schema.key_column_usage, the same catalog the rest of
+// this file's introspection queries already read from.
+func (d *Dialect) foreignKeys(table string) ([]reverse.ForeignKey, error) {
+	rows, err := d.db.Query(`
+		SELECT column_name, referenced_table_name, referenced_column_name
+		FROM information_schema.key_column_usage
+		WHERE table_schema = DATABASE() AND table_name = ? AND referenced_table_name IS NOT NULL`, table)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: foreign keys for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var fks []reverse.ForeignKey
+	for rows.Next() {
+		var fk reverse.ForeignKey
+		if err := rows.Scan(&fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
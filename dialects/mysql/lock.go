@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// lockName is the GET_LOCK name ngorm_migrations uses across all
+// MySQL-backed Migrators, chosen once so every process migrating the
+// same database contends for the same named lock.
+const lockName = "ngorm_migrations"
+
+// connPinner is satisfied by *sql.DB. Lock type-asserts d.db against it
+// so it can check out and hold a single physical connection for the
+// lock's lifetime: GET_LOCK is scoped to the session that acquired it,
+// so issuing Lock and Unlock as two independent Exec calls against the
+// pooled model.SQLCommon risks database/sql handing them to different
+// connections, in which case RELEASE_LOCK silently returns 0 on the
+// wrong session and the lock stays held until the pool happens to close
+// the orphaned one.
+type connPinner interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+}
+
+// Lock acquires a MySQL named lock (GET_LOCK), blocking until it's
+// available, so concurrent processes running Migrate against the same
+// database serialize instead of double-applying a migration. The lock
+// is taken on a single pinned connection so Unlock is guaranteed to
+// release it rather than risk no-oping on a different one.
+func (d *Dialect) Lock(ctx context.Context) error {
+	pinner, ok := d.db.(connPinner)
+	if !ok {
+		return scanGetLock(d.db.QueryRow("SELECT GET_LOCK(?, -1)", lockName))
+	}
+	conn, err := pinner.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	row := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", lockName)
+	if err := scanGetLock(row); err != nil {
+		conn.Close()
+		return err
+	}
+	d.lockConn = conn
+	return nil
+}
+
+// Unlock releases the named lock Lock acquired, on the same connection
+// that acquired it, then returns that connection to the pool.
+func (d *Dialect) Unlock(ctx context.Context) error {
+	if d.lockConn == nil {
+		return scanReleaseLock(d.db.QueryRow("SELECT RELEASE_LOCK(?)", lockName))
+	}
+	conn := d.lockConn
+	d.lockConn = nil
+	err := scanReleaseLock(conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", lockName))
+	if closeErr := conn.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("mysql: unlock: %w", err)
+	}
+	return nil
+}
+
+// scanGetLock reads GET_LOCK's row value rather than trusting Exec's
+// lack of a driver error: GET_LOCK reports 1 (acquired), 0 (timed out)
+// or NULL (a server-side error) in the row itself. Lock calls GET_LOCK
+// with a timeout of -1 (block forever), so any result other than 1
+// means the server refused the lock rather than granted it.
+func scanGetLock(row *sql.Row) error {
+	var result sql.NullInt64
+	if err := row.Scan(&result); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return fmt.Errorf("mysql: GET_LOCK(%q) returned NULL", lockName)
+	}
+	if result.Int64 != 1 {
+		return fmt.Errorf("mysql: GET_LOCK(%q) returned %d, lock not acquired", lockName, result.Int64)
+	}
+	return nil
+}
+
+// scanReleaseLock reads RELEASE_LOCK's row value. 0 means this session
+// didn't hold the lock, a legitimate Unlock-without-Lock case that
+// isn't treated as an error; NULL means the lock never existed at all,
+// which is worth surfacing separately from a genuine connection error.
+func scanReleaseLock(row *sql.Row) error {
+	var result sql.NullInt64
+	if err := row.Scan(&result); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return fmt.Errorf("mysql: RELEASE_LOCK(%q) returned NULL: lock does not exist", lockName)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package mysql implements the ngorm dialects.Dialect for MySQL and
+// MariaDB. Importing this package for its side effect registers "mysql"
+// with the dialects registry:
+//
+//	import _ "github.com/gernest/ngorm/dialects/mysql"
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/gernest/ngorm/dialects"
+	"github.com/gernest/ngorm/model"
+)
+
+func init() {
+	dialects.Register("mysql", func() dialects.Dialect { return &Dialect{} })
+}
+
+// Dialect adapts MySQL to the ngorm dialects.Dialect interface.
+type Dialect struct {
+	db model.SQLCommon
+
+	// lockConn is the single physical connection Lock pinned to hold
+	// the session-scoped named lock; Unlock releases it and hands the
+	// connection back. Nil outside a held Lock.
+	lockConn *sql.Conn
+}
+
+// SetDB stores the connection the dialect will issue introspection
+// queries against.
+func (d *Dialect) SetDB(db model.SQLCommon) {
+	d.db = db
+}
+
+// GetName returns the dialect's registry name.
+func (d *Dialect) GetName() string {
+	return "mysql"
+}
+
+// Quote wraps an identifier in MySQL's backtick quoting.
+func (d *Dialect) Quote(key string) string {
+	return fmt.Sprintf("`%s`", key)
+}
+
+// BindVar returns the positional placeholder MySQL's driver expects.
+// MySQL placeholders are not numbered, so i is ignored.
+func (d *Dialect) BindVar(i int) string {
+	return dialects.QuestionMarkBindVar(i)
+}
+
+// AutoIncrement returns the column suffix MySQL uses to mark a primary
+// key as auto-incrementing.
+func (d *Dialect) AutoIncrement() string {
+	return "AUTO_INCREMENT"
+}
+
+// LimitAndOffsetSQL renders MySQL's "LIMIT n OFFSET m" clause.
+func (d *Dialect) LimitAndOffsetSQL(limit, offset int) string {
+	return dialects.StandardLimitAndOffsetSQL(limit, offset)
+}
+
+// DataTypeOf maps field to the MySQL column type scope.CreateTable
+// should emit for it.
+func (d *Dialect) DataTypeOf(field *model.StructField) string {
+	t := field.Struct.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if sqlType := dialects.CommonDataTypeOf(t); sqlType != "" {
+		return sqlType
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		if field.IsPrimaryKey {
+			return "INT " + d.AutoIncrement()
+		}
+		return "INT"
+	case reflect.Int64, reflect.Uint64:
+		if field.IsPrimaryKey {
+			return "BIGINT " + d.AutoIncrement()
+		}
+		return "BIGINT"
+	case reflect.String:
+		if field.Size == 0 || field.Size > 65535 {
+			return "TEXT"
+		}
+		return fmt.Sprintf("VARCHAR(%d)", field.Size)
+	default:
+		return "TEXT"
+	}
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gernest/ngorm/reverse"
+)
+
+// Introspect reads INFORMATION_SCHEMA for the "public" schema and
+// returns one reverse.TableInfo per base table.
+func (d *Dialect) Introspect(ctx context.Context) ([]reverse.TableInfo, error) {
+	rows, err := d.db.Query(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tables := make([]reverse.TableInfo, 0, len(names))
+	for _, name := range names {
+		cols, err := d.columns(name)
+		if err != nil {
+			return nil, err
+		}
+		indexes, err := d.indexes(name)
+		if err != nil {
+			return nil, err
+		}
+		fks, err := d.foreignKeys(name)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, reverse.TableInfo{
+			Name:        name,
+			Columns:     cols,
+			Indexes:     indexes,
+			ForeignKeys: fks,
+		})
+	}
+	return tables, nil
+}
+
+func (d *Dialect) columns(table string) ([]reverse.Column, error) {
+	rows, err := d.db.Query(`
+		SELECT c.column_name, c.data_type, c.is_nullable, c.character_maximum_length,
+			COALESCE((
+				SELECT true FROM information_schema.table_constraints tc
+				JOIN information_schema.key_column_usage kcu
+					ON tc.constraint_name = kcu.constraint_name
+				WHERE tc.table_name = c.table_name
+					AND tc.constraint_type = 'PRIMARY KEY'
+					AND kcu.column_name = c.column_name
+			), false) AS is_primary
+		FROM information_schema.columns c
+		WHERE c.table_schema = 'public' AND c.table_name = $1
+		ORDER BY c.ordinal_position`, table)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: columns for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var cols []reverse.Column
+	for rows.Next() {
+		var name, dataType, nullable string
+		var size *int
+		var primary bool
+		if err := rows.Scan(&name, &dataType, &nullable, &size, &primary); err != nil {
+			return nil, err
+		}
+		c := reverse.Column{
+			Name:       name,
+			Type:       dataType,
+			Nullable:   nullable == "YES",
+			PrimaryKey: primary,
+		}
+		if size != nil {
+			c.Size = *size
+		}
+		cols = append(cols, c)
+	}
+	return cols, rows.Err()
+}
+
+// indexes reads table's non-primary indexes from the system catalog,
+// since Postgres doesn't expose index column order through
+// information_schema.
+func (d *Dialect) indexes(table string) ([]reverse.Index, error) {
+	rows, err := d.db.Query(`
+		SELECT i.relname, ix.indisunique, a.attname
+		FROM pg_class t
+		JOIN pg_index ix ON t.oid = ix.indrelid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE t.relname = $1 AND NOT ix.indisprimary
+		ORDER BY i.relname, k.ord`, table)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: indexes for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var order []string
+	byName := make(map[string]*reverse.Index)
+	for rows.Next() {
+		var name, column string
+		var unique bool
+		if err := rows.Scan(&name, &unique, &column); err != nil {
+			return nil, err
+		}
+		idx, ok := byName[name]
+		if !ok {
+			idx = &reverse.Index{Name: name, Unique: unique}
+			byName[name] = idx
+			order = append(order, name)
+		}
+		idx.Columns = append(idx.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]reverse.Index, 0, len(order))
+	for _, name := range order {
+		indexes = append(indexes, *byName[name])
+	}
+	return indexes, nil
+}
+
+// foreignKeys reads table's foreign key constraints from
+// information_schema, as Introspect and columns do (indexes reads the
+// pg_catalog tables instead).
+func (d *Dialect) foreignKeys(table string) ([]reverse.ForeignKey, error) {
+	rows, err := d.db.Query(`
+		SELECT kcu.column_name, ccu.table_name, ccu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+		JOIN information_schema.constraint_column_usage ccu
+			ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = 'public' AND tc.table_name = $1`, table)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: foreign keys for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var fks []reverse.ForeignKey
+	for rows.Next() {
+		var fk reverse.ForeignKey
+		if err := rows.Scan(&fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
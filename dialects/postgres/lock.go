@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// lockKey is the advisory lock id ngorm_migrations uses across all
+// Postgres-backed Migrators. It's an arbitrary constant chosen once so
+// every process migrating the same database contends for the same
+// lock, the same way a pg_advisory_lock is meant to be used.
+const lockKey = 7814671
+
+// connPinner is satisfied by *sql.DB. Lock type-asserts d.db against it
+// so it can check out and hold a single physical connection for the
+// lock's lifetime: pg_advisory_lock is scoped to the session that
+// acquired it, so issuing Lock and Unlock as two independent Exec calls
+// against the pooled model.SQLCommon risks database/sql handing them to
+// different connections, in which case Unlock silently no-ops on the
+// wrong session and the lock stays held until the pool happens to close
+// the orphaned one.
+type connPinner interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+}
+
+// Lock acquires a session-level Postgres advisory lock, blocking until
+// it's available, so concurrent processes running Migrate against the
+// same database serialize instead of double-applying a migration. The
+// lock is taken on a single pinned connection so Unlock is guaranteed
+// to release it rather than risk no-oping on a different one.
+func (d *Dialect) Lock(ctx context.Context) error {
+	pinner, ok := d.db.(connPinner)
+	if !ok {
+		return scanAdvisoryLock(d.db.QueryRow("SELECT pg_advisory_lock($1)", lockKey))
+	}
+	conn, err := pinner.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	row := conn.QueryRowContext(ctx, "SELECT pg_advisory_lock($1)", lockKey)
+	if err := scanAdvisoryLock(row); err != nil {
+		conn.Close()
+		return err
+	}
+	d.lockConn = conn
+	return nil
+}
+
+// Unlock releases the advisory lock Lock acquired, on the same
+// connection that acquired it, then returns that connection to the
+// pool.
+func (d *Dialect) Unlock(ctx context.Context) error {
+	if d.lockConn == nil {
+		return scanAdvisoryUnlock(d.db.QueryRow("SELECT pg_advisory_unlock($1)", lockKey))
+	}
+	conn := d.lockConn
+	d.lockConn = nil
+	err := scanAdvisoryUnlock(conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey))
+	if closeErr := conn.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: unlock: %w", err)
+	}
+	return nil
+}
+
+// scanAdvisoryLock reads pg_advisory_lock's row rather than trusting
+// Exec's lack of a driver error: pg_advisory_lock blocks until it
+// acquires the lock or the query itself fails, and Scan is what
+// surfaces a failed/aborted query instead of silently discarding the
+// row Exec would have gotten back anyway.
+func scanAdvisoryLock(row *sql.Row) error {
+	var discard interface{}
+	return row.Scan(&discard)
+}
+
+// scanAdvisoryUnlock reads pg_advisory_unlock's boolean row value.
+// false means this session didn't hold the lock, a legitimate
+// Unlock-without-Lock case that isn't treated as an error; a Scan error
+// means the query itself failed, e.g. a lost connection, which is
+// distinct and still surfaced.
+func scanAdvisoryUnlock(row *sql.Row) error {
+	var released bool
+	return row.Scan(&released)
+}
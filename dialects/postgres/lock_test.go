@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeLockDriver backs a *sql.DB with connections that answer
+// pg_advisory_lock/pg_advisory_unlock-shaped queries without a real
+// Postgres server, so the test can drive Lock/Unlock and inspect which
+// physical connection actually ran each statement.
+type fakeLockDriver struct {
+	mu      sync.Mutex
+	nextID  int
+	queries []queryRecord
+}
+
+type queryRecord struct {
+	connID int
+	query  string
+}
+
+func (d *fakeLockDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	d.nextID++
+	id := d.nextID
+	d.mu.Unlock()
+	return &fakeLockConn{driver: d, id: id}, nil
+}
+
+func (d *fakeLockDriver) record(r queryRecord) {
+	d.mu.Lock()
+	d.queries = append(d.queries, r)
+	d.mu.Unlock()
+}
+
+type fakeLockConn struct {
+	driver *fakeLockDriver
+	id     int
+}
+
+func (c *fakeLockConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeLockStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeLockConn) Close() error { return nil }
+
+func (c *fakeLockConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakeLockConn: transactions not supported")
+}
+
+type fakeLockStmt struct {
+	conn  *fakeLockConn
+	query string
+}
+
+func (s *fakeLockStmt) Close() error  { return nil }
+func (s *fakeLockStmt) NumInput() int { return -1 }
+
+func (s *fakeLockStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.record(queryRecord{connID: s.conn.id, query: s.query})
+	return driver.RowsAffected(0), nil
+}
+
+// Query stands in for pg_advisory_lock/pg_advisory_unlock. It answers
+// with a literal integer the query names (e.g. "SELECT 0", for tests
+// that need a specific result) or 1 otherwise, the case
+// pg_advisory_lock/pg_advisory_unlock hit in normal operation.
+func (s *fakeLockStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.driver.record(queryRecord{connID: s.conn.id, query: s.query})
+	val := int64(1)
+	var n int64
+	if _, err := fmt.Sscanf(s.query, "SELECT %d", &n); err == nil {
+		val = n
+	}
+	return &fakeLockRows{val: val}, nil
+}
+
+// fakeLockRows yields a single row with one integer column, enough to
+// stand in for pg_advisory_lock's void result or pg_advisory_unlock's
+// boolean, both of which database/sql can convert from an int64.
+type fakeLockRows struct {
+	val  int64
+	done bool
+}
+
+func (r *fakeLockRows) Columns() []string { return []string{"result"} }
+func (r *fakeLockRows) Close() error      { return nil }
+
+func (r *fakeLockRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.val
+	return nil
+}
+
+var fakeLockDriverSeq int
+
+// openFakeLockDB registers a fresh fakeLockDriver under a unique name so
+// each test gets its own query log instead of sharing one across the
+// package's test binary.
+func openFakeLockDB(t *testing.T) *sql.DB {
+	t.Helper()
+	fakeLockDriverSeq++
+	name := "ngorm-postgres-lock-fake-" + strconv.Itoa(fakeLockDriverSeq)
+	sql.Register(name, &fakeLockDriver{})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("open fake db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// noConnDB adapts a *sql.DB to model.SQLCommon without exposing Conn,
+// so Lock/Unlock must fall back to issuing the query directly against
+// the pooled connection instead of pinning one.
+type noConnDB struct {
+	db *sql.DB
+}
+
+func (n *noConnDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return n.db.Exec(query, args...)
+}
+
+func (n *noConnDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return n.db.Query(query, args...)
+}
+
+func (n *noConnDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return n.db.QueryRow(query, args...)
+}
+
+func (n *noConnDB) Prepare(query string) (*sql.Stmt, error) {
+	return n.db.Prepare(query)
+}
+
+func (n *noConnDB) Begin() (*sql.Tx, error) {
+	return n.db.Begin()
+}
+
+func TestLockUnlockPinSameConnection(t *testing.T) {
+	db := openFakeLockDB(t)
+	d := &Dialect{}
+	d.SetDB(db)
+
+	ctx := context.Background()
+	if err := d.Lock(ctx); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if d.lockConn == nil {
+		t.Fatal("Lock: expected lockConn to be pinned")
+	}
+	if err := d.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	if d.lockConn != nil {
+		t.Fatal("Unlock: expected lockConn to be cleared")
+	}
+
+	fd := db.Driver().(*fakeLockDriver)
+	fd.mu.Lock()
+	queries := append([]queryRecord{}, fd.queries...)
+	fd.mu.Unlock()
+
+	if len(queries) != 2 {
+		t.Fatalf("expected pg_advisory_lock and pg_advisory_unlock to run, got %d queries: %+v", len(queries), queries)
+	}
+	if queries[0].connID != queries[1].connID {
+		t.Fatalf("Lock and Unlock ran on different connections: %d != %d", queries[0].connID, queries[1].connID)
+	}
+}
+
+func TestLockFallsBackWithoutConnPinner(t *testing.T) {
+	db := openFakeLockDB(t)
+	d := &Dialect{}
+	d.SetDB(&noConnDB{db: db})
+
+	ctx := context.Background()
+	if err := d.Lock(ctx); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if d.lockConn != nil {
+		t.Fatal("Lock: expected lockConn to stay nil without a connPinner")
+	}
+	if err := d.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+
+	fd := db.Driver().(*fakeLockDriver)
+	fd.mu.Lock()
+	queries := len(fd.queries)
+	fd.mu.Unlock()
+	if queries != 2 {
+		t.Fatalf("expected pg_advisory_lock and pg_advisory_unlock to run via the pool, got %d queries", queries)
+	}
+}
+
+func TestScanAdvisoryUnlockAcceptsFalse(t *testing.T) {
+	db := openFakeLockDB(t)
+	if err := scanAdvisoryUnlock(db.QueryRow("SELECT 0")); err != nil {
+		t.Fatalf("expected scanAdvisoryUnlock to accept a false/0 result as non-error: %v", err)
+	}
+}
+
+func TestScanAdvisoryLockSucceedsOnAnyRow(t *testing.T) {
+	db := openFakeLockDB(t)
+	if err := scanAdvisoryLock(db.QueryRow("SELECT 0")); err != nil {
+		t.Fatalf("expected scanAdvisoryLock to succeed on any row the query returns: %v", err)
+	}
+}
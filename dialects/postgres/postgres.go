@@ -0,0 +1,95 @@
+// Package postgres implements the ngorm dialects.Dialect for PostgreSQL.
+// Importing this package for its side effect registers "postgres" with
+// the dialects registry:
+//
+//	import _ "github.com/gernest/ngorm/dialects/postgres"
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/gernest/ngorm/dialects"
+	"github.com/gernest/ngorm/model"
+)
+
+func init() {
+	dialects.Register("postgres", func() dialects.Dialect { return &Dialect{} })
+}
+
+// Dialect adapts PostgreSQL to the ngorm dialects.Dialect interface.
+type Dialect struct {
+	db model.SQLCommon
+
+	// lockConn is the single physical connection Lock pinned to hold
+	// the session-scoped advisory lock; Unlock releases it and hands
+	// the connection back. Nil outside a held Lock.
+	lockConn *sql.Conn
+}
+
+// SetDB stores the connection the dialect will issue introspection
+// queries against.
+func (d *Dialect) SetDB(db model.SQLCommon) {
+	d.db = db
+}
+
+// GetName returns the dialect's registry name.
+func (d *Dialect) GetName() string {
+	return "postgres"
+}
+
+// Quote wraps an identifier in Postgres's double-quote quoting.
+func (d *Dialect) Quote(key string) string {
+	return dialects.DoubleQuote(key)
+}
+
+// BindVar returns Postgres's numbered "$N" placeholder, 1-indexed.
+func (d *Dialect) BindVar(i int) string {
+	return fmt.Sprintf("$%d", i+1)
+}
+
+// AutoIncrement returns the column type Postgres uses in place of
+// MySQL-style AUTO_INCREMENT.
+func (d *Dialect) AutoIncrement() string {
+	return "SERIAL"
+}
+
+// LimitAndOffsetSQL renders Postgres's "LIMIT n OFFSET m" clause.
+func (d *Dialect) LimitAndOffsetSQL(limit, offset int) string {
+	return dialects.StandardLimitAndOffsetSQL(limit, offset)
+}
+
+// DataTypeOf maps field to the Postgres column type scope.CreateTable
+// should emit for it. A primary-key integer maps to one of Postgres's
+// serial types instead of INT/BIGINT plus AUTO_INCREMENT, since that's
+// how Postgres spells auto-incrementing columns.
+func (d *Dialect) DataTypeOf(field *model.StructField) string {
+	t := field.Struct.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if sqlType := dialects.CommonDataTypeOf(t); sqlType != "" {
+		return sqlType
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		if field.IsPrimaryKey {
+			return d.AutoIncrement()
+		}
+		return "INTEGER"
+	case reflect.Int64, reflect.Uint64:
+		if field.IsPrimaryKey {
+			return "BIGSERIAL"
+		}
+		return "BIGINT"
+	case reflect.String:
+		if field.Size == 0 || field.Size > 65535 {
+			return "TEXT"
+		}
+		return fmt.Sprintf("VARCHAR(%d)", field.Size)
+	default:
+		return "TEXT"
+	}
+}
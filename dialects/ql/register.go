@@ -0,0 +1,11 @@
+package ql
+
+import "github.com/gernest/ngorm/dialects"
+
+// init registers the ql dialect with the package dialects registry so
+// DefaultOpener can resolve "ql" and "ql-mem" the same way every other
+// backend is resolved, instead of through a hard-coded switch.
+func init() {
+	dialects.Register("ql", func() dialects.Dialect { return File() })
+	dialects.Register("ql-mem", func() dialects.Dialect { return Memory() })
+}
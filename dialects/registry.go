@@ -0,0 +1,43 @@
+package dialects
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Factory builds a fresh Dialect instance. Dialect packages call Register
+// from an init() so they become available to DefaultOpener without the
+// core ngorm package importing them directly.
+type Factory func() Dialect
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Factory)
+)
+
+// Register makes a dialect factory available under name. It panics if
+// factory is nil or if name has already been registered, mirroring the
+// guards database/sql.Register uses for drivers.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if factory == nil {
+		panic("dialects: Register factory is nil for " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic("dialects: Register called twice for dialect " + name)
+	}
+	registry[name] = factory
+}
+
+// Get resolves the factory registered under name and returns a fresh
+// Dialect instance.
+func Get(name string) (Dialect, error) {
+	mu.RLock()
+	factory, ok := registry[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("dialects: unsupported dialect %s", name)
+	}
+	return factory(), nil
+}
@@ -0,0 +1,166 @@
+package sqlite3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gernest/ngorm/reverse"
+)
+
+// Introspect lists user tables via sqlite_master and reads each one's
+// columns via PRAGMA table_info, since SQLite has no INFORMATION_SCHEMA.
+func (d *Dialect) Introspect(ctx context.Context) ([]reverse.TableInfo, error) {
+	rows, err := d.db.Query(`
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3: list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tables := make([]reverse.TableInfo, 0, len(names))
+	for _, name := range names {
+		cols, err := d.columns(name)
+		if err != nil {
+			return nil, err
+		}
+		indexes, err := d.indexes(name)
+		if err != nil {
+			return nil, err
+		}
+		fks, err := d.foreignKeys(name)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, reverse.TableInfo{
+			Name:        name,
+			Columns:     cols,
+			Indexes:     indexes,
+			ForeignKeys: fks,
+		})
+	}
+	return tables, nil
+}
+
+func (d *Dialect) columns(table string) ([]reverse.Column, error) {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", d.Quote(table)))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3: columns for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var cols []reverse.Column
+	for rows.Next() {
+		var cid int
+		var name, colType string
+		var notNull, pk int
+		var dflt interface{}
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		cols = append(cols, reverse.Column{
+			Name:       name,
+			Type:       colType,
+			Nullable:   notNull == 0,
+			PrimaryKey: pk != 0,
+		})
+	}
+	return cols, rows.Err()
+}
+
+// indexes lists table's indexes via PRAGMA index_list and reads each
+// one's columns via PRAGMA index_info. The implicit index SQLite
+// creates for a PRIMARY KEY (origin "pk") is skipped, since that's
+// already represented by Column.PrimaryKey; the implicit index of a
+// UNIQUE constraint (origin "u") is kept.
+func (d *Dialect) indexes(table string) ([]reverse.Index, error) {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA index_list(%s)", d.Quote(table)))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3: index list for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	type listed struct {
+		name   string
+		unique bool
+	}
+	var names []listed
+	for rows.Next() {
+		var seq int
+		var name, origin string
+		var unique, partial int
+		if err := rows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+			return nil, err
+		}
+		if origin == "pk" {
+			continue
+		}
+		names = append(names, listed{name: name, unique: unique != 0})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]reverse.Index, 0, len(names))
+	for _, l := range names {
+		cols, err := d.indexColumns(l.name)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, reverse.Index{Name: l.name, Unique: l.unique, Columns: cols})
+	}
+	return indexes, nil
+}
+
+func (d *Dialect) indexColumns(index string) ([]string, error) {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA index_info(%s)", d.Quote(index)))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3: index info for %s: %w", index, err)
+	}
+	defer rows.Close()
+
+	var cols []string
+	for rows.Next() {
+		var seqno, cid int
+		var name string
+		if err := rows.Scan(&seqno, &cid, &name); err != nil {
+			return nil, err
+		}
+		cols = append(cols, name)
+	}
+	return cols, rows.Err()
+}
+
+// foreignKeys lists table's foreign key constraints via PRAGMA
+// foreign_key_list.
+func (d *Dialect) foreignKeys(table string) ([]reverse.ForeignKey, error) {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA foreign_key_list(%s)", d.Quote(table)))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3: foreign keys for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var fks []reverse.ForeignKey
+	for rows.Next() {
+		var id, seq int
+		var refTable, from, to string
+		var onUpdate, onDelete, match string
+		if err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+			return nil, err
+		}
+		fks = append(fks, reverse.ForeignKey{Column: from, RefTable: refTable, RefColumn: to})
+	}
+	return fks, rows.Err()
+}
@@ -0,0 +1,83 @@
+// Package sqlite3 implements the ngorm dialects.Dialect for SQLite.
+// Importing this package for its side effect registers "sqlite3" with
+// the dialects registry:
+//
+//	import _ "github.com/gernest/ngorm/dialects/sqlite3"
+package sqlite3
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gernest/ngorm/dialects"
+	"github.com/gernest/ngorm/model"
+)
+
+func init() {
+	dialects.Register("sqlite3", func() dialects.Dialect { return &Dialect{} })
+}
+
+// Dialect adapts SQLite to the ngorm dialects.Dialect interface.
+type Dialect struct {
+	db model.SQLCommon
+}
+
+// SetDB stores the connection the dialect will issue introspection
+// queries against.
+func (d *Dialect) SetDB(db model.SQLCommon) {
+	d.db = db
+}
+
+// GetName returns the dialect's registry name.
+func (d *Dialect) GetName() string {
+	return "sqlite3"
+}
+
+// Quote wraps an identifier in SQLite's double-quote quoting.
+func (d *Dialect) Quote(key string) string {
+	return dialects.DoubleQuote(key)
+}
+
+// BindVar returns the positional placeholder SQLite's driver expects.
+// SQLite placeholders are not numbered, so i is ignored.
+func (d *Dialect) BindVar(i int) string {
+	return dialects.QuestionMarkBindVar(i)
+}
+
+// AutoIncrement returns the column suffix SQLite uses to mark an
+// INTEGER PRIMARY KEY as auto-incrementing.
+func (d *Dialect) AutoIncrement() string {
+	return "AUTOINCREMENT"
+}
+
+// LimitAndOffsetSQL renders SQLite's "LIMIT n OFFSET m" clause.
+func (d *Dialect) LimitAndOffsetSQL(limit, offset int) string {
+	return dialects.StandardLimitAndOffsetSQL(limit, offset)
+}
+
+// DataTypeOf maps field to the SQLite column type scope.CreateTable
+// should emit for it. Every integer kind maps to INTEGER, and an
+// INTEGER PRIMARY KEY is sufficient for auto-increment-like rowid behavior
+// without AutoIncrement's stricter (and slower) AUTOINCREMENT keyword.
+func (d *Dialect) DataTypeOf(field *model.StructField) string {
+	t := field.Struct.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if sqlType := dialects.CommonDataTypeOf(t); sqlType != "" {
+		return sqlType
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Int64, reflect.Uint64:
+		return "INTEGER"
+	case reflect.String:
+		if field.Size == 0 || field.Size > 65535 {
+			return "TEXT"
+		}
+		return fmt.Sprintf("VARCHAR(%d)", field.Size)
+	default:
+		return "TEXT"
+	}
+}
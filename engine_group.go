@@ -0,0 +1,217 @@
+package ngorm
+
+import (
+	"database/sql"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/gernest/ngorm/engine"
+	"github.com/gernest/ngorm/model"
+)
+
+// Policy decides which slave should serve the next read-only engine
+// handed out by an EngineGroup.
+type Policy interface {
+	Pick(slaves []*DB) *DB
+}
+
+// RoundRobin cycles through the slaves in registration order. It is
+// safe for concurrent use.
+type RoundRobin struct {
+	mu sync.Mutex
+	i  int
+}
+
+// Pick returns the next slave in rotation.
+func (p *RoundRobin) Pick(slaves []*DB) *DB {
+	if len(slaves) == 0 {
+		return nil
+	}
+	p.mu.Lock()
+	d := slaves[p.i%len(slaves)]
+	p.i++
+	p.mu.Unlock()
+	return d
+}
+
+// Random picks a slave uniformly at random.
+type Random struct{}
+
+// Pick returns a uniformly random slave.
+func (Random) Pick(slaves []*DB) *DB {
+	if len(slaves) == 0 {
+		return nil
+	}
+	return slaves[rand.Intn(len(slaves))]
+}
+
+// WeightRandom picks a slave at random, biased by Weights. Weights[i] is
+// the relative weight of slaves[i]; a slave with no matching entry (or a
+// non-positive one) defaults to weight 1.
+type WeightRandom struct {
+	Weights []int
+}
+
+// Pick returns a weighted-random slave.
+func (p WeightRandom) Pick(slaves []*DB) *DB {
+	if len(slaves) == 0 {
+		return nil
+	}
+	weights := make([]int, len(slaves))
+	total := 0
+	for i := range slaves {
+		w := 1
+		if i < len(p.Weights) && p.Weights[i] > 0 {
+			w = p.Weights[i]
+		}
+		weights[i] = w
+		total += w
+	}
+	r := rand.Intn(total)
+	for i, w := range weights {
+		if r < w {
+			return slaves[i]
+		}
+		r -= w
+	}
+	return slaves[len(slaves)-1]
+}
+
+// EngineGroup holds one write DB and a pool of read DBs that share the
+// same structMap, hooks and logger, giving ngorm the read/write
+// separation story xorm's EngineGroup provides. The zero value is not
+// usable; create one with OpenGroup.
+type EngineGroup struct {
+	master *DB
+	slaves []*DB
+	policy Policy
+}
+
+// OpenGroup opens a master connection and one connection per slave DSN,
+// all for the same dialect, and wires the slaves to share the master's
+// structMap, hooks and logger. The default routing policy is
+// RoundRobin; change it with SetPolicy.
+func OpenGroup(dialect string, master string, slaves []string) (*EngineGroup, error) {
+	m, err := Open(dialect, master)
+	if err != nil {
+		return nil, err
+	}
+	g := &EngineGroup{master: m, policy: &RoundRobin{}}
+	for _, dsn := range slaves {
+		s, err := Open(dialect, dsn)
+		if err != nil {
+			return nil, err
+		}
+		s.structMap = m.structMap
+		s.hooks = m.hooks
+		s.log = m.log
+		g.slaves = append(g.slaves, s)
+	}
+	return g, nil
+}
+
+// SetPolicy changes the load-balancing policy used to pick a slave.
+func (g *EngineGroup) SetPolicy(p Policy) {
+	g.policy = p
+}
+
+// NewEngine returns an engine backed by a connection that routes each
+// statement as it runs: INSERT/UPDATE/DELETE/DDL statements go to the
+// master, everything else to a slave chosen by the group's policy. A
+// transaction always begins on the master, so a caller that starts one
+// off this engine gets read-your-writes consistency for its whole
+// lifetime without asking for Master explicitly.
+func (g *EngineGroup) NewEngine() *engine.Engine {
+	return g.master.engineWithConn(&routingConn{group: g})
+}
+
+// Master returns an engine pinned to the master connection. Prefer this
+// when you already know a call site is write-only, to skip the
+// statement sniffing NewEngine does.
+func (g *EngineGroup) Master() *engine.Engine {
+	return g.master.NewEngine()
+}
+
+// Slave returns an engine pinned to a slave chosen by the group's
+// policy, for callers that want to force read routing explicitly rather
+// than go through NewEngine.
+func (g *EngineGroup) Slave() *engine.Engine {
+	return g.read().NewEngine()
+}
+
+func (g *EngineGroup) read() *DB {
+	if len(g.slaves) == 0 {
+		return g.master
+	}
+	if d := g.policy.Pick(g.slaves); d != nil {
+		return d
+	}
+	return g.master
+}
+
+// routingConn is a model.SQLCommon that defers each statement to the
+// master or to a policy-chosen slave, decided by sniffing the
+// statement's leading keyword. It lets EngineGroup.NewEngine hand out a
+// single engine that still splits reads from writes per-statement,
+// instead of requiring callers to pick Master or Slave up front.
+type routingConn struct {
+	group *EngineGroup
+}
+
+func (c *routingConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.connFor(query).Exec(query, args...)
+}
+
+func (c *routingConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.connFor(query).Query(query, args...)
+}
+
+func (c *routingConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.connFor(query).QueryRow(query, args...)
+}
+
+func (c *routingConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.connFor(query).Prepare(query)
+}
+
+// Begin always starts the transaction on the master, so every statement
+// run through the returned *sql.Tx stays on that one connection for the
+// transaction's whole lifetime.
+func (c *routingConn) Begin() (*sql.Tx, error) {
+	return c.group.master.db.Begin()
+}
+
+func (c *routingConn) connFor(query string) model.SQLCommon {
+	if isWriteStatement(query) {
+		return c.group.master.db
+	}
+	return c.group.read().db
+}
+
+var writeKeywords = []string{
+	"INSERT", "UPDATE", "DELETE", "REPLACE",
+	"CREATE", "ALTER", "DROP", "TRUNCATE",
+}
+
+// isWriteStatement reports whether query's leading keyword is one of
+// writeKeywords and so must go to the master. Any other leading
+// keyword, including WITH, is treated as a read.
+func isWriteStatement(query string) bool {
+	word := leadingKeyword(query)
+	for _, k := range writeKeywords {
+		if word == k {
+			return true
+		}
+	}
+	return false
+}
+
+func leadingKeyword(query string) string {
+	query = strings.TrimSpace(query)
+	end := strings.IndexAny(query, " \t\n(")
+	if end < 0 {
+		end = len(query)
+	}
+	return strings.ToUpper(query[:end])
+}
@@ -0,0 +1,54 @@
+package ngorm
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRoundRobinConcurrent(t *testing.T) {
+	slaves := []*DB{{}, {}, {}}
+	p := &RoundRobin{}
+
+	var wg sync.WaitGroup
+	counts := make([]int, len(slaves))
+	var mu sync.Mutex
+	for i := 0; i < 300; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := p.Pick(slaves)
+			for idx, s := range slaves {
+				if s == d {
+					mu.Lock()
+					counts[idx]++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total != 300 {
+		t.Fatalf("expected 300 picks distributed across slaves, got %d", total)
+	}
+}
+
+func TestIsWriteStatement(t *testing.T) {
+	cases := map[string]bool{
+		"SELECT * FROM users":          false,
+		"  select id from users":       false,
+		"INSERT INTO users VALUES (?)": true,
+		"update users set name = ?":    true,
+		"DELETE FROM users":            true,
+		"CREATE TABLE users (id int)":  true,
+	}
+	for query, want := range cases {
+		if got := isWriteStatement(query); got != want {
+			t.Errorf("isWriteStatement(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
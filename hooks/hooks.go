@@ -0,0 +1,49 @@
+// Package hooks implements the named callback chains ngorm runs around
+// each write, letting behavior like optimistic locking hang off the
+// normal update path instead of requiring callers to opt into a
+// separate one.
+package hooks
+
+import (
+	"github.com/gernest/ngorm/builder"
+	"github.com/gernest/ngorm/search"
+)
+
+// UpdateFunc is one callback in a Book's Update chain. It receives the
+// update built so far and the model driving it, and returns the
+// (possibly modified) builder to pass to the next callback.
+type UpdateFunc func(b *builder.Builder, model interface{}, opts *search.Options) (*builder.Builder, error)
+
+// Book holds the callback chain ngorm runs for each write operation.
+// Every DB opened through ngorm gets a DefaultBook; DB has no exported
+// way to swap in a different Book.
+type Book struct {
+	update []UpdateFunc
+}
+
+// DefaultBook returns the Book every DB opened through ngorm uses.
+// Its Update chain runs VersionGuard, so any model with an
+// `ngorm:"version"` field gets optimistic-locking protection
+// automatically, with no per-call opt-in required.
+func DefaultBook() *Book {
+	return &Book{update: []UpdateFunc{VersionGuard}}
+}
+
+// RegisterUpdate appends fn to the end of the Update chain.
+func (b *Book) RegisterUpdate(fn UpdateFunc) {
+	b.update = append(b.update, fn)
+}
+
+// Update runs every callback in b's Update chain over base in order,
+// stopping at the first error, and returns the builder ready for the
+// caller to render and execute.
+func (b *Book) Update(base *builder.Builder, model interface{}, opts *search.Options) (*builder.Builder, error) {
+	var err error
+	for _, fn := range b.update {
+		base, err = fn(base, model, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return base, nil
+}
@@ -0,0 +1,118 @@
+package hooks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gernest/ngorm/builder"
+	"github.com/gernest/ngorm/search"
+)
+
+// VersionOf returns model's ngorm:"version" column and its current
+// value, or hasVersion=false if model has no such field. It applies the
+// same zero-version refusal as VersionGuard, since a version field that
+// reads zero usually means the caller forgot to load it rather than
+// that it's genuinely current. It's exported so other update paths —
+// such as ngormgen's generated QuerySet.Update — can apply the same
+// optimistic-locking predicate and bump without going through the
+// builder.Builder that VersionGuard is wired for.
+func VersionOf(model interface{}) (column string, current int64, hasVersion bool, err error) {
+	column, value, ok := versionField(model)
+	if !ok {
+		return "", 0, false, nil
+	}
+	current = value.Int()
+	if err := refuseZeroVersion(model, current); err != nil {
+		return "", 0, false, err
+	}
+	return column, current, true, nil
+}
+
+// VersionGuard is the Update callback that implements optimistic
+// locking. When model has an int64 field tagged `ngorm:"version"`, it
+// adds "AND version = <current>" to the WHERE clause and
+// "version = version + 1" to the SET clause, and records the checked
+// value on opts so the caller can tell a zero-rows-affected result
+// apart from an ordinary no-match. A version field that reads zero is
+// refused unless search.WithVersion made the expected value explicit,
+// since zero usually means the caller forgot to load it rather than
+// that it's genuinely current.
+func VersionGuard(b *builder.Builder, model interface{}, opts *search.Options) (*builder.Builder, error) {
+	column, value, ok := versionField(model)
+	if !ok {
+		if opts.HasVersion {
+			return nil, fmt.Errorf("hooks: search.WithVersion given but %T has no ngorm:\"version\" field", model)
+		}
+		return b, nil
+	}
+
+	current := value.Int()
+	if opts.HasVersion {
+		current = opts.Version
+	} else if err := refuseZeroVersion(model, current); err != nil {
+		return nil, err
+	}
+
+	opts.Versioned = true
+	opts.CheckedVersion = current
+	return b.And(builder.Eq{column: current}).Set(column, builder.Raw(column+" + 1")), nil
+}
+
+// refuseZeroVersion reports the shared error VersionGuard and VersionOf
+// both give for a version field that reads zero with no explicit
+// override, so the two call sites can't drift apart on when a zero
+// version is and isn't acceptable.
+func refuseZeroVersion(model interface{}, current int64) error {
+	if current != 0 {
+		return nil
+	}
+	return fmt.Errorf("hooks: refusing to update %T with a zero version; pass search.WithVersion to override", model)
+}
+
+func versionField(model interface{}) (column string, value reflect.Value, ok bool) {
+	v := reflect.Indirect(reflect.ValueOf(model))
+	if v.Kind() != reflect.Struct {
+		return "", reflect.Value{}, false
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("ngorm")
+		if !hasTagOption(tag, "version") || f.Type.Kind() != reflect.Int64 {
+			continue
+		}
+		return columnFromTag(f.Name, tag), v.Field(i), true
+	}
+	return "", reflect.Value{}, false
+}
+
+func hasTagOption(tag, option string) bool {
+	for _, part := range strings.Split(tag, ";") {
+		if strings.TrimSpace(part) == option {
+			return true
+		}
+	}
+	return false
+}
+
+func columnFromTag(fieldName, tag string) string {
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "column:") {
+			return strings.TrimPrefix(part, "column:")
+		}
+	}
+	return toSnakeCase(fieldName)
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
@@ -0,0 +1,109 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/gernest/ngorm/builder"
+	"github.com/gernest/ngorm/dialects/mysql"
+	"github.com/gernest/ngorm/search"
+)
+
+type versionedModel struct {
+	ID      int64
+	Version int64 `ngorm:"version"`
+}
+
+type plainModel struct {
+	ID int64
+}
+
+func TestVersionGuardAddsPredicateAndBump(t *testing.T) {
+	base := builder.Update("versioned_models").Where(builder.Eq{"id": 1})
+	var opts search.Options
+
+	got, err := VersionGuard(base, &versionedModel{ID: 1, Version: 3}, &opts)
+	if err != nil {
+		t.Fatalf("VersionGuard returned error: %v", err)
+	}
+	if !opts.Versioned || opts.CheckedVersion != 3 {
+		t.Fatalf("expected opts to record checked version 3, got %+v", opts)
+	}
+
+	sql, args, err := got.ToSQL(&mysql.Dialect{})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+	wantSQL := "UPDATE versioned_models SET `version` = version + 1 WHERE (`id` = ? AND `version` = ?)"
+	if sql != wantSQL {
+		t.Errorf("sql = %q, want %q", sql, wantSQL)
+	}
+	if len(args) != 2 || args[0] != 1 || args[1] != int64(3) {
+		t.Errorf("args = %v, want [1 3]", args)
+	}
+}
+
+func TestVersionGuardHonorsWithVersion(t *testing.T) {
+	base := builder.Update("versioned_models").Where(builder.Eq{"id": 1})
+	var opts search.Options
+	search.WithVersion(7)(&opts)
+
+	_, err := VersionGuard(base, &versionedModel{ID: 1, Version: 3}, &opts)
+	if err != nil {
+		t.Fatalf("VersionGuard returned error: %v", err)
+	}
+	if opts.CheckedVersion != 7 {
+		t.Fatalf("expected WithVersion(7) to override the model's version, got %d", opts.CheckedVersion)
+	}
+}
+
+func TestVersionGuardRejectsZeroVersion(t *testing.T) {
+	base := builder.Update("versioned_models").Where(builder.Eq{"id": 1})
+	var opts search.Options
+
+	if _, err := VersionGuard(base, &versionedModel{ID: 1}, &opts); err == nil {
+		t.Fatal("expected an error for a zero version with no WithVersion override")
+	}
+}
+
+func TestVersionGuardSkipsUnversionedModel(t *testing.T) {
+	base := builder.Update("plain_models").Where(builder.Eq{"id": 1})
+	var opts search.Options
+
+	got, err := VersionGuard(base, &plainModel{ID: 1}, &opts)
+	if err != nil {
+		t.Fatalf("VersionGuard returned error: %v", err)
+	}
+	if got != base {
+		t.Fatal("expected VersionGuard to pass the builder through unchanged")
+	}
+	if opts.Versioned {
+		t.Fatal("expected opts.Versioned to stay false for an unversioned model")
+	}
+}
+
+func TestVersionOfReturnsColumnAndCurrentValue(t *testing.T) {
+	column, current, hasVersion, err := VersionOf(&versionedModel{ID: 1, Version: 3})
+	if err != nil {
+		t.Fatalf("VersionOf returned error: %v", err)
+	}
+	if !hasVersion || column != "version" || current != 3 {
+		t.Fatalf("got column=%q current=%d hasVersion=%v, want column=\"version\" current=3 hasVersion=true",
+			column, current, hasVersion)
+	}
+}
+
+func TestVersionOfSkipsUnversionedModel(t *testing.T) {
+	_, _, hasVersion, err := VersionOf(&plainModel{ID: 1})
+	if err != nil {
+		t.Fatalf("VersionOf returned error: %v", err)
+	}
+	if hasVersion {
+		t.Fatal("expected hasVersion to be false for an unversioned model")
+	}
+}
+
+func TestVersionOfRejectsZeroVersion(t *testing.T) {
+	if _, _, _, err := VersionOf(&versionedModel{ID: 1}); err == nil {
+		t.Fatal("expected an error for a zero version, the same refusal VersionGuard applies")
+	}
+}
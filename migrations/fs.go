@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gernest/ngorm/engine"
+)
+
+// MigrateFS registers every `<id>.up.sql` / `<id>.down.sql` pair found in
+// fsys and then runs Migrate, letting users mix Go-defined and
+// SQL-defined migrations on the same Migrator. Files are matched by
+// their shared id (the name before ".up.sql"/".down.sql") and applied in
+// lexical order, so ids are expected to sort the way they should run
+// (e.g. a leading timestamp).
+func (m *Migrator) MigrateFS(ctx context.Context, fsys fs.FS) error {
+	migs, err := readFS(fsys)
+	if err != nil {
+		return err
+	}
+	for _, mig := range migs {
+		m.Register(mig)
+	}
+	return m.Migrate(ctx)
+}
+
+func readFS(fsys fs.FS) ([]Migration, error) {
+	ups := map[string]string{}
+	downs := map[string]string{}
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		name := path.Base(p)
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			ups[strings.TrimSuffix(name, ".up.sql")] = p
+		case strings.HasSuffix(name, ".down.sql"):
+			downs[strings.TrimSuffix(name, ".down.sql")] = p
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("migrations: read fs: %w", err)
+	}
+
+	ids := make([]string, 0, len(ups))
+	for id := range ups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	migs := make([]Migration, 0, len(ids))
+	for _, id := range ids {
+		upPath := ups[id]
+		downPath := downs[id]
+		migs = append(migs, Migration{
+			ID: id,
+			Up: func(e *engine.Engine) error {
+				return execSQLFile(fsys, upPath, e)
+			},
+			Down: func(e *engine.Engine) error {
+				if downPath == "" {
+					return fmt.Errorf("migrations: no down file for %s", id)
+				}
+				return execSQLFile(fsys, downPath, e)
+			},
+		})
+	}
+	return migs, nil
+}
+
+func execSQLFile(fsys fs.FS, p string, e *engine.Engine) error {
+	b, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return fmt.Errorf("migrations: read %s: %w", p, err)
+	}
+	_, err = e.SQLDB.Exec(string(b))
+	return err
+}
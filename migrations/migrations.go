@@ -0,0 +1,193 @@
+// Package migrations manages ordered, named schema migrations on top of
+// ngorm's (*ngorm.DB).ExecTx, QueryTx and BeginTx, recording which
+// ones have run in a `ngorm_migrations` table so the same set can be
+// applied exactly once across processes.
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gernest/ngorm"
+	"github.com/gernest/ngorm/engine"
+)
+
+const tableName = "ngorm_migrations"
+
+// Migration is a single named schema step. Up applies it, Down reverses
+// it. Both receive a ready-to-use *engine.Engine the same way the rest
+// of ngorm's functional-style API does.
+type Migration struct {
+	ID   string
+	Up   func(e *engine.Engine) error
+	Down func(e *engine.Engine) error
+}
+
+// Locker lets a dialect provide an advisory-lock style guard so that
+// concurrent processes running Migrate against the same database don't
+// double-apply a migration. Dialects that don't implement Locker simply
+// rely on the per-step transaction to make application idempotent.
+type Locker interface {
+	Lock(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
+
+// Migrator tracks the migrations registered against db and drives them
+// forward or backward.
+type Migrator struct {
+	db         *ngorm.DB
+	migrations []Migration
+}
+
+// New returns a Migrator bound to db.
+func New(db *ngorm.DB) *Migrator {
+	return &Migrator{db: db}
+}
+
+// Register adds m to the ordered list of migrations. Registration order
+// is the order Migrate applies them in, so callers are expected to
+// register in, e.g., timestamp order.
+func (m *Migrator) Register(mig Migration) {
+	m.migrations = append(m.migrations, mig)
+}
+
+func (m *Migrator) ensureTable() error {
+	_, err := m.db.ExecTx(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, applied_at TIMESTAMP)", tableName))
+	return err
+}
+
+func (m *Migrator) applied() (map[string]bool, error) {
+	rows, err := m.db.QueryTx(fmt.Sprintf("SELECT id FROM %s", tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	done := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		done[id] = true
+	}
+	return done, rows.Err()
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if l, ok := m.db.NewEngine().Dialect.(Locker); ok {
+		if err := l.Lock(ctx); err != nil {
+			return fmt.Errorf("migrations: acquire lock: %w", err)
+		}
+		defer l.Unlock(ctx)
+	}
+	return fn()
+}
+
+// Migrate applies every registered migration that hasn't run yet, in
+// registration order. Each migration's step and its bookkeeping INSERT
+// run inside the same transaction, so a crash partway through never
+// leaves a step applied without being recorded, or vice versa.
+func (m *Migrator) Migrate(ctx context.Context) error {
+	if err := m.ensureTable(); err != nil {
+		return fmt.Errorf("migrations: ensure tracking table: %w", err)
+	}
+	return m.withLock(ctx, func() error {
+		done, err := m.appliedIDs()
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if done[mig.ID] {
+				continue
+			}
+			bindVar := m.db.Dialect().BindVar
+			record := fmt.Sprintf("INSERT INTO %s (id, applied_at) VALUES (%s, %s)",
+				tableName, bindVar(0), bindVar(1))
+			if err := m.runStep(mig.Up, record, mig.ID, time.Now()); err != nil {
+				return fmt.Errorf("migrations: apply %s: %w", mig.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Rollback reverses the last n applied migrations, most recent first.
+// Each migration's Down step and its bookkeeping DELETE run inside the
+// same transaction, for the same reason Migrate does.
+func (m *Migrator) Rollback(ctx context.Context, n int) error {
+	if err := m.ensureTable(); err != nil {
+		return fmt.Errorf("migrations: ensure tracking table: %w", err)
+	}
+	return m.withLock(ctx, func() error {
+		done, err := m.appliedIDs()
+		if err != nil {
+			return err
+		}
+		applied := make([]Migration, 0, len(m.migrations))
+		for _, mig := range m.migrations {
+			if done[mig.ID] {
+				applied = append(applied, mig)
+			}
+		}
+		for i := len(applied) - 1; i >= 0 && n > 0; i-- {
+			mig := applied[i]
+			unrecord := fmt.Sprintf("DELETE FROM %s WHERE id = %s", tableName, m.db.Dialect().BindVar(0))
+			if err := m.runStep(mig.Down, unrecord, mig.ID); err != nil {
+				return fmt.Errorf("migrations: rollback %s: %w", mig.ID, err)
+			}
+			n--
+		}
+		return nil
+	})
+}
+
+// runStep runs step against an engine bound to a single transaction,
+// then executes bookkeeping (the tracking-table INSERT or DELETE) on
+// that same transaction, committing only if both succeed.
+func (m *Migrator) runStep(step func(e *engine.Engine) error, bookkeeping string, args ...interface{}) error {
+	tx, e, err := m.db.BeginTx()
+	if err != nil {
+		return err
+	}
+	if step != nil {
+		if err := step(e); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.Exec(bookkeeping, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) appliedIDs() (map[string]bool, error) {
+	return m.applied()
+}
+
+// Status reports, for every registered migration in order, whether it
+// has been applied.
+type Status struct {
+	ID      string
+	Applied bool
+}
+
+// Status diffs registered migrations against the ones recorded as
+// applied.
+func (m *Migrator) Status() ([]Status, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, fmt.Errorf("migrations: ensure tracking table: %w", err)
+	}
+	done, err := m.appliedIDs()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		out[i] = Status{ID: mig.ID, Applied: done[mig.ID]}
+	}
+	return out, nil
+}
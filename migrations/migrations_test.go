@@ -0,0 +1,176 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gernest/ngorm"
+	"github.com/gernest/ngorm/engine"
+
+	_ "github.com/gernest/ngorm/dialects/sqlite3"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *ngorm.DB {
+	t.Helper()
+	db, err := ngorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite3: %v", err)
+	}
+	return db
+}
+
+func TestMigrateRunsStepAndRecordsBookkeeping(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.ExecTx("CREATE TABLE widgets (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("seed widgets table: %v", err)
+	}
+	if _, err := db.ExecTx("DROP TABLE widgets"); err != nil {
+		t.Fatalf("drop widgets table: %v", err)
+	}
+
+	m := New(db)
+	m.Register(Migration{
+		ID: "001_create_widgets",
+		Up: func(e *engine.Engine) error {
+			_, err := e.SQLDB.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY)")
+			return err
+		},
+		Down: func(e *engine.Engine) error {
+			_, err := e.SQLDB.Exec("DROP TABLE widgets")
+			return err
+		},
+	})
+
+	if err := m.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	if _, err := db.ExecTx("INSERT INTO widgets (id) VALUES (1)"); err != nil {
+		t.Fatalf("widgets table wasn't created by Up: %v", err)
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status) != 1 || !status[0].Applied {
+		t.Fatalf("expected 001_create_widgets to be recorded as applied, got %+v", status)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	applies := 0
+	m := New(db)
+	m.Register(Migration{
+		ID: "001_create_widgets",
+		Up: func(e *engine.Engine) error {
+			applies++
+			_, err := e.SQLDB.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY)")
+			return err
+		},
+	})
+
+	ctx := context.Background()
+	if err := m.Migrate(ctx); err != nil {
+		t.Fatalf("first Migrate: %v", err)
+	}
+	if err := m.Migrate(ctx); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+	if applies != 1 {
+		t.Fatalf("expected Up to run exactly once across two Migrate calls, ran %d times", applies)
+	}
+}
+
+func TestRollbackReversesStepAndBookkeeping(t *testing.T) {
+	db := openTestDB(t)
+	m := New(db)
+	m.Register(Migration{
+		ID: "001_create_widgets",
+		Up: func(e *engine.Engine) error {
+			_, err := e.SQLDB.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY)")
+			return err
+		},
+		Down: func(e *engine.Engine) error {
+			_, err := e.SQLDB.Exec("DROP TABLE widgets")
+			return err
+		},
+	})
+
+	ctx := context.Background()
+	if err := m.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := m.Rollback(ctx, 1); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	if _, err := db.ExecTx("INSERT INTO widgets (id) VALUES (1)"); err == nil {
+		t.Fatal("expected widgets table to have been dropped by Down")
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status) != 1 || status[0].Applied {
+		t.Fatalf("expected 001_create_widgets to be recorded as rolled back, got %+v", status)
+	}
+}
+
+// TestRunStepRollsBackStepWithFailedBookkeeping drives runStep directly
+// (rather than through Migrate, which would just skip an already
+// recorded ID) so a bookkeeping write can be forced to fail alongside a
+// step that would otherwise succeed, proving the two share one
+// transaction rather than the step committing on its own.
+func TestRunStepRollsBackStepWithFailedBookkeeping(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.ExecTx("CREATE TABLE widgets (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("seed widgets table: %v", err)
+	}
+	m := New(db)
+	if err := m.ensureTable(); err != nil {
+		t.Fatalf("ensureTable: %v", err)
+	}
+
+	bindVar := db.Dialect().BindVar
+	record := fmt.Sprintf("INSERT INTO %s (id, applied_at) VALUES (%s, %s)", tableName, bindVar(0), bindVar(1))
+
+	if err := m.runStep(func(e *engine.Engine) error {
+		_, err := e.SQLDB.Exec("INSERT INTO widgets (id) VALUES (1)")
+		return err
+	}, record, "001_create_widgets", time.Now()); err != nil {
+		t.Fatalf("first runStep: %v", err)
+	}
+
+	// Reusing the same migration ID collides with the bookkeeping row
+	// the first runStep committed; the insert this step runs alongside
+	// it must roll back too.
+	if err := m.runStep(func(e *engine.Engine) error {
+		_, err := e.SQLDB.Exec("INSERT INTO widgets (id) VALUES (2)")
+		return err
+	}, record, "001_create_widgets", time.Now()); err == nil {
+		t.Fatal("expected runStep to fail on a duplicate bookkeeping id")
+	}
+
+	rows, err := db.QueryTx("SELECT id FROM widgets ORDER BY id")
+	if err != nil {
+		t.Fatalf("query widgets: %v", err)
+	}
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("expected only the first runStep's insert to survive, got %v", ids)
+	}
+}
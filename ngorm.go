@@ -61,8 +61,10 @@
 // implementation is provided.
 //
 //   [dialects] https://godoc.org/github.com/gernest/ngorm/dialects
-// Adopts to different SQL databases supported by ngorm. For now ngorm support
-// ql .
+// Adopts to different SQL databases supported by ngorm. Dialects register
+// themselves through dialects.Register, so ql, mysql, postgres and
+// sqlite3 are all available by importing the dialect package for its
+// side effect.
 package ngorm
 
 import (
@@ -72,7 +74,7 @@ import (
 	"fmt"
 
 	"github.com/gernest/ngorm/dialects"
-	"github.com/gernest/ngorm/dialects/ql"
+	_ "github.com/gernest/ngorm/dialects/ql"
 	"github.com/gernest/ngorm/engine"
 	"github.com/gernest/ngorm/hooks"
 	"github.com/gernest/ngorm/logger"
@@ -123,8 +125,23 @@ func OpenWithOpener(opener Opener, dialect string, args ...interface{}) (*DB, er
 	}, nil
 }
 
+// Dialect returns the dialect this DB was opened with, for callers that
+// need to reach dialect-specific behavior (e.g. reverse's Introspector)
+// that isn't exposed through DB itself.
+func (db *DB) Dialect() dialects.Dialect {
+	return db.dialect
+}
+
 // NewEngine returns an initialized engine ready to kick some ass.
 func (db *DB) NewEngine() *engine.Engine {
+	return db.engineWithConn(db.db)
+}
+
+// engineWithConn builds an engine identical to the one NewEngine
+// returns, except it executes against conn instead of db.db. EngineGroup
+// uses this to hand out engines backed by a routingConn rather than a
+// single fixed connection.
+func (db *DB) engineWithConn(conn model.SQLCommon) *engine.Engine {
 	return &engine.Engine{
 		Search:        &model.Search{},
 		Scope:         &model.Scope{},
@@ -132,7 +149,7 @@ func (db *DB) NewEngine() *engine.Engine {
 		SingularTable: db.singularTable,
 		Ctx:           db.ctx,
 		Dialect:       db.dialect,
-		SQLDB:         db.db,
+		SQLDB:         conn,
 		Log:           db.log,
 	}
 }
@@ -162,6 +179,13 @@ func (db *DB) ExecTx(query string, args ...interface{}) (sql.Result, error) {
 	return r, nil
 }
 
+// QueryTx runs query directly against the underlying connection and
+// returns the resulting rows, for callers such as the migrations package
+// that need to read data back rather than just an exec result.
+func (db *DB) QueryTx(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.db.Query(query, args...)
+}
+
 func (db *DB) CreateTableSQL(models ...interface{}) (*model.Expr, error) {
 	var buf bytes.Buffer
 	buf.WriteString("BEGIN TRANSACTION; \n")
@@ -189,7 +213,6 @@ type DefaultOpener struct {
 
 func (d *DefaultOpener) Open(dialect string, args ...interface{}) (model.SQLCommon, dialects.Dialect, error) {
 	var source string
-	var dia dialects.Dialect
 	var common model.SQLCommon
 	var err error
 
@@ -211,13 +234,9 @@ func (d *DefaultOpener) Open(dialect string, args ...interface{}) (model.SQLComm
 	default:
 		return nil, nil, fmt.Errorf("unknown argument %v", value)
 	}
-	switch dialect {
-	case "ql":
-		dia = ql.File()
-	case "ql-mem":
-		dia = ql.Memory()
-	default:
-		return nil, nil, fmt.Errorf("unsupported dialect %s", dialect)
+	dia, err := dialects.Get(dialect)
+	if err != nil {
+		return nil, nil, err
 	}
 	return common, dia, nil
 }
@@ -0,0 +1,44 @@
+package reverse
+
+import "path/filepath"
+
+// Filter keeps tables matching at least one of include (or all tables,
+// if include is empty) and excludes any matching exclude. Patterns use
+// filepath.Match syntax against the table name.
+func Filter(tables []TableInfo, include, exclude []string) ([]TableInfo, error) {
+	var out []TableInfo
+	for _, t := range tables {
+		keep, err := matchesAny(t.Name, include, true)
+		if err != nil {
+			return nil, err
+		}
+		if !keep {
+			continue
+		}
+		excluded, err := matchesAny(t.Name, exclude, false)
+		if err != nil {
+			return nil, err
+		}
+		if excluded {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func matchesAny(name string, patterns []string, emptyMeans bool) (bool, error) {
+	if len(patterns) == 0 {
+		return emptyMeans, nil
+	}
+	for _, p := range patterns {
+		ok, err := filepath.Match(p, name)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
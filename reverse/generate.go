@@ -0,0 +1,225 @@
+package reverse
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate emits one Go struct per table, with an `ngorm` tag per
+// field carrying the column name plus primary_key/size/not null as
+// needed. Pass a custom template to Generate to add JSON tags, extra
+// interface methods, or anything else project-specific.
+const DefaultTemplate = `// Code generated by ngormreverse. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+{{range .Tables}}
+// {{.StructName}} maps the {{.Table.Name}} table.
+type {{.StructName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`ngorm:\"{{.Tag}}\"`" + `
+{{- end}}
+}
+{{end}}`
+
+// Field is a template-ready rendering of one Column.
+type Field struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// TableData is a template-ready rendering of one TableInfo.
+type TableData struct {
+	Table      TableInfo
+	StructName string
+	Fields     []Field
+}
+
+// Data is the top-level value passed to the template.
+type Data struct {
+	Package string
+	Imports []string
+	Tables  []TableData
+}
+
+// Generate renders tables into Go source using tmplText (DefaultTemplate
+// if empty), gofmt's the result, and returns it.
+func Generate(pkg string, tables []TableInfo, tmplText string) ([]byte, error) {
+	if tmplText == "" {
+		tmplText = DefaultTemplate
+	}
+	t, err := template.New("ngormreverse").Parse(tmplText)
+	if err != nil {
+		return nil, fmt.Errorf("reverse: parse template: %w", err)
+	}
+
+	data := Data{Package: pkg}
+	needsSQL, needsTime := false, false
+	for _, table := range tables {
+		tableFields := fields(table)
+		for _, f := range tableFields {
+			needsSQL = needsSQL || strings.HasPrefix(f.Type, "sql.")
+			needsTime = needsTime || f.Type == "time.Time"
+		}
+		data.Tables = append(data.Tables, TableData{
+			Table:      table,
+			StructName: structName(table.Name),
+			Fields:     tableFields,
+		})
+	}
+	if needsSQL {
+		data.Imports = append(data.Imports, "database/sql")
+	}
+	if needsTime {
+		data.Imports = append(data.Imports, "time")
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("reverse: render template: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("reverse: gofmt generated source: %w", err)
+	}
+	return src, nil
+}
+
+func fields(table TableInfo) []Field {
+	fks := make(map[string]ForeignKey, len(table.ForeignKeys))
+	for _, fk := range table.ForeignKeys {
+		fks[fk.Column] = fk
+	}
+	out := make([]Field, 0, len(table.Columns))
+	for _, c := range table.Columns {
+		out = append(out, Field{
+			Name: fieldName(c.Name),
+			Type: goType(c),
+			Tag:  tag(c, fks[c.Name]),
+		})
+	}
+	return out
+}
+
+func tag(c Column, fk ForeignKey) string {
+	parts := []string{"column:" + c.Name}
+	if c.PrimaryKey {
+		parts = append(parts, "primary_key")
+	}
+	if !c.Nullable {
+		parts = append(parts, "not null")
+	}
+	if c.Size > 0 {
+		parts = append(parts, fmt.Sprintf("size:%d", c.Size))
+	}
+	if fk.RefTable != "" {
+		parts = append(parts, fmt.Sprintf("foreignkey:%s.%s", fk.RefTable, fk.RefColumn))
+	}
+	return strings.Join(parts, ";")
+}
+
+// goType maps a column's SQL type to a Go type, nullable numeric/string
+// columns widening to their sql.Null* counterpart so a NULL value
+// doesn't panic on scan.
+func goType(c Column) string {
+	t := strings.ToLower(c.Type)
+	switch {
+	case strings.Contains(t, "int"):
+		if c.Nullable {
+			return "sql.NullInt64"
+		}
+		return "int64"
+	case strings.Contains(t, "bool"):
+		if c.Nullable {
+			return "sql.NullBool"
+		}
+		return "bool"
+	case strings.Contains(t, "float"), strings.Contains(t, "double"), strings.Contains(t, "decimal"), strings.Contains(t, "numeric"):
+		if c.Nullable {
+			return "sql.NullFloat64"
+		}
+		return "float64"
+	case strings.Contains(t, "time"), strings.Contains(t, "date"):
+		return "time.Time"
+	default:
+		if c.Nullable {
+			return "sql.NullString"
+		}
+		return "string"
+	}
+}
+
+func fieldName(column string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range column {
+		if r == '_' {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			b.WriteRune(toUpper(r))
+			upperNext = false
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// invariantTables holds lowercase table names whose singular form isn't
+// "drop the trailing s" or any of the suffix rules below, either because
+// they're already singular (species, series) or because stripping a
+// suffix would still get it wrong.
+var invariantTables = map[string]string{
+	"species": "Species",
+	"series":  "Series",
+	"news":    "News",
+	"status":  "Status",
+}
+
+// structName derives a singular Go struct name from table, which is
+// assumed to be the plural, snake_case convention ngorm's own table
+// naming uses. It isn't a full English singularizer - just enough suffix
+// handling to avoid mangling the common cases a naive "strip trailing s"
+// gets wrong (status, address, species), plus invariantTables for the
+// handful of real table names no suffix rule covers correctly.
+func structName(table string) string {
+	if name, ok := invariantTables[strings.ToLower(table)]; ok {
+		return name
+	}
+	name := fieldName(table)
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(lower, "xes") || strings.HasSuffix(lower, "ses") ||
+		strings.HasSuffix(lower, "ches") || strings.HasSuffix(lower, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lower, "ss"):
+		// "address", "business": already singular, stripping one s
+		// would just produce another word ending in s.
+		return name
+	case strings.HasSuffix(lower, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	default:
+		return name
+	}
+}
+
+func toUpper(r rune) rune {
+	if r >= 'a' && r <= 'z' {
+		return r - ('a' - 'A')
+	}
+	return r
+}
@@ -0,0 +1,52 @@
+package reverse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructName(t *testing.T) {
+	cases := map[string]string{
+		"users":      "User",
+		"categories": "Category",
+		"boxes":      "Box",
+		"statuses":   "Status",
+		"status":     "Status",
+		"addresses":  "Address",
+		"species":    "Species",
+		"series":     "Series",
+		"news":       "News",
+		"wishes":     "Wish",
+		"matches":    "Match",
+	}
+	for table, want := range cases {
+		if got := structName(table); got != want {
+			t.Errorf("structName(%q) = %q, want %q", table, got, want)
+		}
+	}
+}
+
+func TestFieldsRendersForeignKeyTag(t *testing.T) {
+	table := TableInfo{
+		Name: "posts",
+		Columns: []Column{
+			{Name: "id", Type: "int", PrimaryKey: true, Nullable: false},
+			{Name: "author_id", Type: "int", Nullable: false},
+		},
+		ForeignKeys: []ForeignKey{
+			{Column: "author_id", RefTable: "users", RefColumn: "id"},
+		},
+	}
+
+	got := fields(table)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(got))
+	}
+	want := "column:author_id;not null;foreignkey:users.id"
+	if got[1].Tag != want {
+		t.Errorf("author_id tag = %q, want %q", got[1].Tag, want)
+	}
+	if strings.Contains(got[0].Tag, "foreignkey") {
+		t.Errorf("id tag should have no foreignkey, got %q", got[0].Tag)
+	}
+}
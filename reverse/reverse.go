@@ -0,0 +1,58 @@
+// Package reverse generates ngorm model structs from an existing
+// database schema, so users can adopt ngorm on legacy databases without
+// hand-writing structs. It reads schema metadata through the
+// Introspector interface, which a dialects.Dialect implements when it
+// knows how to describe its own catalog.
+//
+// reverse.TableInfo, not model.TableInfo, is deliberately the shape this
+// package works in, and Introspector is a separate, optional interface
+// rather than an addition to dialects.Dialect itself: reverse-engineering
+// is a one-off tool-time operation, not part of the query path every
+// dialect has to support, so a dialect that can't introspect its own
+// catalog (ql, for instance) simply doesn't implement Introspector
+// instead of having to stub out methods on the interface every dialect
+// implements. This mirrors how migrations.Locker is kept optional rather
+// than folded into dialects.Dialect.
+package reverse
+
+import "context"
+
+// Column describes one introspected column.
+type Column struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+	Size       int
+}
+
+// Index describes one introspected index.
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// ForeignKey describes one introspected foreign key constraint.
+type ForeignKey struct {
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// TableInfo is everything reverse needs to know about a table to emit a
+// Go struct for it.
+type TableInfo struct {
+	Name        string
+	Columns     []Column
+	Indexes     []Index
+	ForeignKeys []ForeignKey
+}
+
+// Introspector is implemented by dialects that can describe their own
+// schema well enough for reverse-engineering Go structs from it: MySQL
+// and Postgres via INFORMATION_SCHEMA, SQLite via sqlite_master and its
+// PRAGMAs. The ql dialect does not implement it.
+type Introspector interface {
+	Introspect(ctx context.Context) ([]TableInfo, error)
+}
@@ -0,0 +1,35 @@
+// Package search holds the functional options that customize a single
+// query or write, the same style as the Where/Order helpers the rest of
+// ngorm's search layer already uses.
+package search
+
+// Options carries the settings a chain of options mutates. Options is
+// passed by pointer so a hook such as hooks.VersionGuard can both read
+// what the caller asked for and record what it actually did (see
+// Versioned/CheckedVersion) for the caller to act on afterward.
+type Options struct {
+	// Version and HasVersion are set by WithVersion.
+	Version    int64
+	HasVersion bool
+
+	// Versioned and CheckedVersion are set by hooks.VersionGuard once it
+	// adds the optimistic-locking predicate, so the caller driving the
+	// statement knows whether a zero-rows-affected result means the
+	// object was stale and which version was being checked.
+	Versioned      bool
+	CheckedVersion int64
+}
+
+// Option customizes an Options value.
+type Option func(*Options)
+
+// WithVersion supplies the version an update should be checked against
+// explicitly, for callers updating individual columns rather than
+// passing a whole struct whose `ngorm:"version"` field already holds the
+// current value.
+func WithVersion(v int64) Option {
+	return func(o *Options) {
+		o.Version = v
+		o.HasVersion = true
+	}
+}
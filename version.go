@@ -0,0 +1,85 @@
+package ngorm
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/gernest/ngorm/builder"
+	"github.com/gernest/ngorm/scope"
+	"github.com/gernest/ngorm/search"
+)
+
+// ErrStaleObject is returned by Save when no row matched both the
+// primary key and the expected version, meaning some other writer
+// updated the row first.
+type ErrStaleObject struct {
+	Model   interface{}
+	Version int64
+}
+
+func (e *ErrStaleObject) Error() string {
+	return fmt.Sprintf("ngorm: stale object %T at version %d", e.Model, e.Version)
+}
+
+// Save updates the row identified by pkColumn/pkValue with updates,
+// running db.hooks.Update over the statement first. With the default
+// hooks.Book that means models with an `ngorm:"version"` field get
+// optimistic locking for free: the WHERE clause also requires the
+// row's current version to match, the SET clause bumps it with
+// "version = version + 1", and an affected-row count of zero is
+// reported as *ErrStaleObject rather than treated as success. Pass
+// search.WithVersion to check against an explicit version instead of
+// the one read off model, for callers updating individual columns
+// rather than a whole struct.
+func (db *DB) Save(model interface{}, pkColumn string, pkValue interface{}, updates map[string]interface{}, opts ...search.Option) (sql.Result, error) {
+	var o search.Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	b := builder.Update(db.tableName(model)).Where(builder.Eq{pkColumn: pkValue})
+	for _, col := range sortedKeys(updates) {
+		b = b.Set(col, updates[col])
+	}
+
+	b, err := db.hooks.Update(b, model, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := db.ExecBuilder(b)
+	if err != nil {
+		return nil, err
+	}
+	if o.Versioned {
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return res, &ErrStaleObject{Model: model, Version: o.CheckedVersion}
+		}
+	}
+	return res, nil
+}
+
+// sortedKeys returns updates' keys in sorted order, so the generated
+// SET clause has a deterministic column order instead of depending on
+// Go's randomized map iteration.
+func sortedKeys(updates map[string]interface{}) []string {
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tableName derives the table name from model's type by asking scope
+// for it, the same resolver CreateTable, Find and every other query
+// path already go through, instead of guessing at a pluralization rule
+// of its own.
+func (db *DB) tableName(model interface{}) string {
+	return scope.TableName(db.NewEngine(), model)
+}
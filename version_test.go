@@ -0,0 +1,94 @@
+package ngorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	_ "github.com/gernest/ngorm/dialects/sqlite3"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type versionedRow struct {
+	ID      int64
+	Name    string
+	Version int64 `ngorm:"version"`
+}
+
+type plainRow struct {
+	ID   int64
+	Name string
+}
+
+func openVersionTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite3: %v", err)
+	}
+	return db
+}
+
+func TestSaveBumpsVersionAndRejectsStaleWrite(t *testing.T) {
+	db := openVersionTestDB(t)
+	table := db.tableName(&versionedRow{})
+	if _, err := db.ExecTx(fmt.Sprintf(
+		"CREATE TABLE %s (id INTEGER PRIMARY KEY, name TEXT, version INTEGER)", table)); err != nil {
+		t.Fatalf("create %s: %v", table, err)
+	}
+	if _, err := db.ExecTx(fmt.Sprintf(
+		"INSERT INTO %s (id, name, version) VALUES (1, 'a', 1)", table)); err != nil {
+		t.Fatalf("seed row: %v", err)
+	}
+
+	if _, err := db.Save(&versionedRow{ID: 1, Name: "a", Version: 1}, "id", int64(1),
+		map[string]interface{}{"name": "b"}); err != nil {
+		t.Fatalf("first Save: %v", err)
+	}
+
+	rows, err := db.QueryTx(fmt.Sprintf("SELECT name, version FROM %s WHERE id = 1", table))
+	if err != nil {
+		t.Fatalf("query after first Save: %v", err)
+	}
+	var name string
+	var version int64
+	if !rows.Next() {
+		t.Fatal("expected a row after first Save")
+	}
+	if err := rows.Scan(&name, &version); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	rows.Close()
+	if name != "b" || version != 2 {
+		t.Fatalf("got name=%q version=%d, want name=\"b\" version=2", name, version)
+	}
+
+	// model's Version is still 1, so this Save checks against a version
+	// that's no longer current.
+	_, err = db.Save(&versionedRow{ID: 1, Name: "b", Version: 1}, "id", int64(1),
+		map[string]interface{}{"name": "c"})
+	var stale *ErrStaleObject
+	if !errors.As(err, &stale) {
+		t.Fatalf("expected *ErrStaleObject for a stale write, got %v", err)
+	}
+	if stale.Version != 1 {
+		t.Fatalf("expected ErrStaleObject to report checked version 1, got %d", stale.Version)
+	}
+}
+
+func TestSaveSkipsVersionGuardForUnversionedModel(t *testing.T) {
+	db := openVersionTestDB(t)
+	table := db.tableName(&plainRow{})
+	if _, err := db.ExecTx(fmt.Sprintf(
+		"CREATE TABLE %s (id INTEGER PRIMARY KEY, name TEXT)", table)); err != nil {
+		t.Fatalf("create %s: %v", table, err)
+	}
+
+	// No row matches id 1, so a versioned Save would report
+	// *ErrStaleObject for zero rows affected; an unversioned model
+	// should just report success with nothing changed.
+	if _, err := db.Save(&plainRow{ID: 1, Name: "a"}, "id", int64(1),
+		map[string]interface{}{"name": "b"}); err != nil {
+		t.Fatalf("expected Save on an unversioned model to succeed, got %v", err)
+	}
+}